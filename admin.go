@@ -0,0 +1,227 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// Keys method returns a page of the cache keys matching the glob-style
+// pattern, e.g. `user-*`, starting from the cursor, use zero cursor for the
+// first page. It returns the cursor of the next page, zero if it's the last
+// page. Count is a hint of the page size. Keys are returned as stored, i.e.
+// hashed if the key hashing is enabled. Pagination is supported only for the
+// single node client.
+func (r *redisCache) Keys(cursor uint64, match string, count int64) ([]string, uint64, error) {
+	c, ok := r.client().(*redis.Client)
+	if !ok {
+		return nil, 0, fmt.Errorf("aah/cache/%s: keys pagination is supported only in standalone mode", r.Name())
+	}
+	if len(match) == 0 {
+		match = "*"
+	}
+	prefix := r.entryPrefix()
+	keys, next, err := c.Scan(cursor, escapeGlob(prefix)+match, count).Result()
+	r.p.done(err)
+	if err != nil {
+		return nil, 0, fmt.Errorf("aah/cache/%s: keys %v", r.Name(), err)
+	}
+	for i, k := range keys {
+		keys[i] = strings.TrimPrefix(k, prefix)
+	}
+	return keys, next, nil
+}
+
+// AdminHandler method returns the HTTP handler of the cache admin API, so
+// that the support teams could inspect the caches and purge the specific key
+// in production without Redis access. It's enabled via config
+// `admin.enable = true`, otherwise it responds 404. Requests are
+// authenticated with `Authorization: Bearer <admin.token>` when the token is
+// configured, deletion is disabled with `admin.read_only = true`. Handler is
+// read-only without `admin.token`, so the entries could not be purged by the
+// unauthenticated requests.
+//
+//	GET    /caches                   caches with its stats
+//	GET    /caches/<name>/keys       keys, query params `cursor`, `match` and `count`
+//	GET    /caches/<name>/keys/<key> entry metadata, see `Inspect`
+//	DELETE /caches/<name>/keys/<key> deletes the entry
+//
+// Mount it on the application route with the path prefix stripped, e.g.
+//
+//	http.StripPrefix("/admin/cache", p.AdminHandler())
+func (p *Provider) AdminHandler() http.Handler {
+	a := &admin{
+		p:        p,
+		enabled:  p.appCfg.BoolDefault(p.cfgPrefix+"admin.enable", false),
+		token:    p.appCfg.StringDefault(p.cfgPrefix+"admin.token", ""),
+		readOnly: p.appCfg.BoolDefault(p.cfgPrefix+"admin.read_only", false),
+	}
+	if a.enabled && len(a.token) == 0 && !a.readOnly {
+		p.logger.Warnf("aah/cache/provider: %s admin.token is not configured, admin handler is read-only", p.name)
+		a.readOnly = true
+	}
+	return a
+}
+
+type admin struct {
+	p        *Provider
+	enabled  bool
+	token    string
+	readOnly bool
+}
+
+type adminCache struct {
+	Name     string  `json:"name"`
+	Stats    Stats   `json:"stats"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+type adminKeys struct {
+	Keys   []string `json:"keys"`
+	Cursor uint64   `json:"cursor"`
+}
+
+type adminEntry struct {
+	Key       string     `json:"key"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Hits      int64      `json:"hits"`
+	TTL       string     `json:"ttl"`
+}
+
+var errAdminNotFound = errors.New("not found")
+
+func (a *admin) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !a.enabled {
+		http.NotFound(w, req)
+		return
+	}
+	if len(a.token) > 0 && subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), []byte("Bearer "+a.token)) != 1 {
+		a.error(w, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+
+	parts := strings.SplitN(strings.Trim(req.URL.EscapedPath(), "/"), "/", 4)
+	if parts[0] != "caches" {
+		a.error(w, http.StatusNotFound, errAdminNotFound)
+		return
+	}
+	if len(parts) == 1 {
+		a.caches(w, req)
+		return
+	}
+	r := a.cache(parts[1])
+	if r == nil || len(parts) < 3 || parts[2] != "keys" {
+		a.error(w, http.StatusNotFound, errAdminNotFound)
+		return
+	}
+	if len(parts) == 3 {
+		a.keys(w, req, r)
+		return
+	}
+	k, err := url.PathUnescape(parts[3])
+	if err != nil {
+		a.error(w, http.StatusBadRequest, err)
+		return
+	}
+	a.entry(w, req, r, k)
+}
+
+func (a *admin) caches(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		a.error(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	a.p.mu.Lock()
+	caches := make([]adminCache, 0, len(a.p.caches))
+	for _, r := range a.p.caches {
+		s := r.Stats()
+		caches = append(caches, adminCache{Name: r.Name(), Stats: s, HitRatio: s.HitRatio()})
+	}
+	a.p.mu.Unlock()
+	a.json(w, http.StatusOK, caches)
+}
+
+func (a *admin) keys(w http.ResponseWriter, req *http.Request, r *redisCache) {
+	if req.Method != http.MethodGet {
+		a.error(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	q := req.URL.Query()
+	cursor, _ := strconv.ParseUint(q.Get("cursor"), 10, 64)
+	count, err := strconv.ParseInt(q.Get("count"), 10, 64)
+	if err != nil || count <= 0 {
+		count = 100
+	}
+	keys, next, err := r.Keys(cursor, q.Get("match"), count)
+	if err != nil {
+		a.error(w, http.StatusInternalServerError, err)
+		return
+	}
+	a.json(w, http.StatusOK, adminKeys{Keys: keys, Cursor: next})
+}
+
+func (a *admin) entry(w http.ResponseWriter, req *http.Request, r *redisCache, k string) {
+	switch req.Method {
+	case http.MethodGet:
+		info, err := r.Inspect(k)
+		if err == ErrCacheMiss {
+			a.error(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			a.error(w, http.StatusInternalServerError, err)
+			return
+		}
+		e := adminEntry{Key: k, Hits: info.Hits, TTL: info.TTL.String()}
+		if !info.CreatedAt.IsZero() {
+			e.CreatedAt = &info.CreatedAt
+		}
+		a.json(w, http.StatusOK, e)
+	case http.MethodDelete:
+		if a.readOnly {
+			a.error(w, http.StatusForbidden, errors.New("admin is read only"))
+			return
+		}
+		if err := r.Delete(k); err != nil {
+			a.error(w, http.StatusInternalServerError, err)
+			return
+		}
+		a.p.logger.Infof("aah/cache/provider: %s admin deleted key(%s) from cache %s", a.p.name, k, r.Name())
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		a.error(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+func (a *admin) cache(name string) *redisCache {
+	a.p.mu.Lock()
+	defer a.p.mu.Unlock()
+	for _, r := range a.p.caches {
+		if r.Name() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+func (a *admin) json(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (a *admin) error(w http.ResponseWriter, status int, err error) {
+	a.json(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisAdminHandler(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			admin {
+				enable = true
+				token = "s3cret"
+			}
+			metadata {
+				enable = true
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "admincache", ProviderName: "redis1"}))
+	c := mgr.Cache("admincache").(Cache)
+	h := mgr.Provider("redis1").(*Provider).AdminHandler()
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Nil(t, c.Put("user/1", "value1", time.Minute))
+	assert.Nil(t, c.Put("user/2", "value2", time.Minute))
+	assert.Nil(t, c.Put("order-1", "value3", time.Minute))
+	assert.Equal(t, "value1", c.Get("user/1"))
+
+	// unauthorized
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/caches", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = do(http.MethodGet, "/caches")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var caches []adminCache
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &caches))
+	assert.Equal(t, "admincache", caches[0].Name)
+	assert.Equal(t, uint64(3), caches[0].Stats.Puts)
+
+	w = do(http.MethodGet, "/caches/admincache/keys?match=user*&count=10")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var keys adminKeys
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &keys))
+	sort.Strings(keys.Keys)
+	assert.Equal(t, []string{"user/1", "user/2"}, keys.Keys)
+
+	w = do(http.MethodGet, "/caches/admincache/keys/user%2F1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var e adminEntry
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &e))
+	assert.Equal(t, "user/1", e.Key)
+	assert.Equal(t, int64(1), e.Hits)
+	assert.NotNil(t, e.CreatedAt)
+
+	w = do(http.MethodDelete, "/caches/admincache/keys/user%2F1")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.False(t, c.Exists("user/1"))
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/caches/admincache/keys/user%2F1").Code)
+
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/caches/unknown/keys").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, do(http.MethodPost, "/caches").Code)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisAdminHandlerGated(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	w := httptest.NewRecorder()
+	mgr.Provider("redis1").(*Provider).AdminHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/caches", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mgr = createCacheMgr(t, "redis2", `
+	cache {
+		redis2 {
+			provider = "redis"
+			address = "localhost:6379"
+			admin {
+				enable = true
+				read_only = true
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "roadmincache", ProviderName: "redis2"}))
+	w = httptest.NewRecorder()
+	mgr.Provider("redis2").(*Provider).AdminHandler().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/caches/roadmincache/keys/key1", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRedisAdminHandlerWithoutToken(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			admin {
+				enable = true
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "notokencache", ProviderName: "redis1"}))
+	c := mgr.Cache("notokencache")
+	assert.Nil(t, c.Put("key1", "value1", time.Minute))
+	h := mgr.Provider("redis1").(*Provider).AdminHandler()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/caches/notokencache/keys/key1", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.True(t, c.Exists("key1"))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/caches", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.Nil(t, c.Flush())
+}
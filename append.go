@@ -0,0 +1,125 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-redis/redis"
+)
+
+// ErrNotString returned by Append and SetRange when the cache entry is not a
+// raw string or []byte value, e.g. gob encoded, compressed or encrypted.
+var ErrNotString = errors.New("aah/cache: entry is not a string value")
+
+// stringEntryHead is the Lua snippet that reads the entry header, it returns
+// -1 if the entry does not exists and -2 if it's not a raw string or []byte
+// entry of the format version ARGV[4], version header kind is ARGV[3]. Version
+// header is skipped, header length including the version header is in `idx`.
+const stringEntryHead = `local h = redis.call("getrange", KEYS[1], 0, 79)
+if h == "" then
+	return -1
+end
+local off, version = 0, "0"
+if string.byte(h, 1) == 0 and string.byte(h, 2) == tonumber(ARGV[3]) then
+	local vidx = string.find(h, ":", 3, true)
+	off = vidx and string.find(h, ":", vidx + 1, true)
+	if not off then
+		return -2
+	end
+	version = string.sub(h, vidx + 1, off - 1)
+end
+local kind = string.byte(h, off + 2)
+if version ~= ARGV[4] or string.byte(h, off + 1) ~= 0 or (kind ~= tonumber(ARGV[1]) and kind ~= tonumber(ARGV[2])) then
+	return -2
+end
+local idx = string.find(h, ":", off + 3, true)
+`
+
+// appendScript appends to the value of the raw string entry, it creates the
+// entry with header ARGV[6] if it does not exists.
+var appendScript = redis.NewScript(`if redis.call("exists", KEYS[1]) == 0 then
+	redis.call("set", KEYS[1], ARGV[6] .. ARGV[5])
+	return string.len(ARGV[5])
+end
+` + stringEntryHead + `return redis.call("append", KEYS[1], ARGV[5]) - idx`)
+
+// setRangeScript overwrites the value of the raw string entry at the offset.
+var setRangeScript = redis.NewScript(stringEntryHead +
+	`return redis.call("setrange", KEYS[1], idx + tonumber(ARGV[5]), ARGV[6]) - idx`)
+
+// Append method appends s to the string or []byte value of the cache entry in
+// place and returns the value length after the append, so that the log and
+// token list accumulators do not need read-modify-write. Entry is created
+// with string value and without expiration if it does not exists, use
+// `Expire` to set it. It returns `ErrNotString` if the entry value is of
+// other type or compressed or encrypted. In the cache with config
+// `format.version`, entry is created with the version header and the entries
+// of other format versions are not updated, `ErrNotString` is returned.
+func (r *redisCache) Append(k, s string) (int64, error) {
+	buf := acquireBuffer()
+	defer releaseBuffer(buf)
+	if r.formatVersion > 0 {
+		head := acquireBuffer()
+		defer releaseBuffer(head)
+		writeHeader(head, byte(reflect.String), 0)
+		r.writeVersion(buf, 0, head.Bytes())
+	} else {
+		writeHeader(buf, byte(reflect.String), 0)
+	}
+	return r.updateString(k, appendScript, s, buf.String())
+}
+
+// SetRange method overwrites the string or []byte value of the cache entry
+// starting at the offset with s and returns the value length after the
+// update, value is zero-padded if the offset is beyond its length. It returns
+// `ErrCacheMiss` if the entry does not exists and `ErrNotString` if the entry
+// value is of other type or compressed or encrypted, or the entry is of other
+// format version than config `format.version`.
+func (r *redisCache) SetRange(k string, offset int64, s string) (int64, error) {
+	if offset < 0 {
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) negative offset %d", r.Name(), k, offset)
+	}
+	return r.updateString(k, setRangeScript, offset, s)
+}
+
+func (r *redisCache) updateString(k string, script *redis.Script, args ...interface{}) (int64, error) {
+	if r.aead != nil {
+		// encrypted entries can't be updated in place
+		return 0, ErrNotString
+	}
+	start := r.begin(opPut, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opPut, k, ErrCircuitOpen, start)
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	args = append([]interface{}{int(reflect.String), int(reflect.Slice), versionedKind, r.formatVersion}, args...)
+	n, err := script.Run(r.client(), []string{r.key(k)}, args...).Int64()
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opPut, k, resultOK, start)
+	switch n {
+	case -1:
+		return 0, ErrCacheMiss
+	case -2:
+		return 0, ErrNotString
+	}
+	if r.local != nil {
+		r.local.Delete(k)
+	}
+	if r.inv != nil {
+		r.inv.publish(k)
+	}
+	r.emit(opPut, k)
+	r.stats.put()
+	return n, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisAppendSetRange(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "appendcache", ProviderName: "redis1"}).(Cache)
+
+	// creates the entry if it does not exists
+	n, err := c.Append("log", "line1;")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), n)
+	n, err = c.Append("log", "line2;")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), n)
+	assert.Equal(t, "line1;line2;", c.Get("log"))
+
+	// expiration is preserved
+	assert.Nil(t, c.Put("tokens", []byte("a,b"), time.Minute))
+	n, err = c.Append("tokens", ",c")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, []byte("a,b,c"), c.Get("tokens"))
+	ttl, err := c.TTL("tokens")
+	assert.Nil(t, err)
+	assert.True(t, ttl > 0)
+
+	n, err = c.SetRange("tokens", 2, "B")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, []byte("a,B,c"), c.Get("tokens"))
+
+	_, err = c.SetRange("not-exists", 0, "x")
+	assert.Equal(t, ErrCacheMiss, err)
+	_, err = c.SetRange("tokens", -1, "x")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, c.Put("count", 10, time.Minute))
+	_, err = c.Append("count", "1")
+	assert.Equal(t, ErrNotString, err)
+	_, err = c.SetRange("count", 0, "2")
+	assert.Equal(t, ErrNotString, err)
+	assert.Equal(t, 10, c.Get("count"))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisAppendSetRangeFormatVersion(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			format {
+				version = 2
+			}
+		}
+	}
+`, &cache.Config{Name: "appendversioncache", ProviderName: "redis1"}).(*redisCache)
+
+	// entry is created with the version header
+	n, err := c.Append("log", "line1;")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), n)
+	n, err = c.Append("log", "line2;")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), n)
+	assert.Equal(t, "line1;line2;", c.Get("log"))
+	b, err := c.client().Get(c.key("log")).Bytes()
+	assert.Nil(t, err)
+	assert.Equal(t, "\x00\xfb0:2:\x00\x180:line1;line2;", string(b))
+
+	assert.Nil(t, c.Put("tokens", []byte("a,b"), time.Minute))
+	n, err = c.Append("tokens", ",c")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), n)
+	n, err = c.SetRange("tokens", 2, "B")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, []byte("a,B,c"), c.Get("tokens"))
+
+	// entries of other format version are not updated in place
+	assert.Nil(t, c.client().Set(c.key("legacy"), "\x00\x180:value", 0).Err())
+	_, err = c.Append("legacy", "1")
+	assert.Equal(t, ErrNotString, err)
+	_, err = c.SetRange("legacy", 0, "V")
+	assert.Equal(t, ErrNotString, err)
+
+	assert.Nil(t, c.Flush())
+}
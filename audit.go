@@ -0,0 +1,141 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/log"
+	"github.com/go-redis/redis"
+)
+
+// Audit trail targets.
+const (
+	auditStream = "stream"
+	auditLog    = "log"
+)
+
+type actorKey struct{}
+
+// WithActor function returns the copy of ctx carrying the actor, i.e. the
+// user or the service performing the cache mutations, see `WithContext`.
+//
+//	c.WithContext(redis.WithActor(ctx, "user:42")).Delete("order-1")
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext function returns the actor carried by ctx, otherwise
+// empty string.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// auditTrail struct records the cache mutations with the actor, timestamp and
+// operation, so that the cache changes could be reconstructed after the
+// incidents.
+//
+// It's enabled per cache via config `audit.enable = true`. Config
+// `audit.target` is `stream` (default) or `log`. Stream target writes the
+// records with fields `op`, `key`, `actor` and `instance` to the Redis Stream
+// `audit.stream`, default is `aah:cache:audit:<cache name>`, it's trimmed to
+// approximately `audit.max_len` (default 100000) records; record time is the
+// stream ID. Log target logs the records at info level with the structured
+// fields. Mutations performed through the view returned by `WithContext` are
+// attributed to its actor, otherwise the actor is empty.
+type auditTrail struct {
+	target string
+	stream string
+	maxLen int64
+}
+
+func (p *Provider) newAuditTrail(cacheName string) (*auditTrail, error) {
+	at := &auditTrail{
+		target: p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "audit.target"), auditStream),
+		stream: p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "audit.stream"), "aah:cache:audit:"+cacheName),
+		maxLen: int64(p.appCfg.IntDefault(p.cacheCfgKey(cacheName, "audit.max_len"), 100000)),
+	}
+	if at.target != auditStream && at.target != auditLog {
+		return nil, fmt.Errorf("unsupported audit.target '%s'", at.target)
+	}
+	return at, nil
+}
+
+// audit method records the cache mutation with its actor to the audit trail,
+// if it's enabled.
+func (r *redisCache) audit(op, k, actor string) {
+	if r.at == nil {
+		return
+	}
+	if r.at.target == auditLog {
+		r.logger.with(log.Fields{"op": op, "key": k, "actor": actor, "instance": r.p.id}).
+			infof("aah/cache/%s: audit %s key(%s) by '%s' at %s", r.Name(), op, k, actor, time.Now().Format(time.RFC3339Nano))
+		return
+	}
+	err := r.client().XAdd(&redis.XAddArgs{
+		Stream:       r.at.stream,
+		MaxLenApprox: r.at.maxLen,
+		Values:       map[string]interface{}{"op": op, "key": k, "actor": actor, "instance": r.p.id},
+	}).Err()
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.logFor(op, k).errorf("aah/cache/%s: key(%s) audit %v", r.Name(), k, err)
+	}
+}
+
+// WithContext method returns the view of the cache which attributes the Put,
+// GetOrPut, Delete and Flush to the actor carried by ctx in the audit trail,
+// see `WithActor`. Spans of the view operations are parented to the span
+// carried by ctx. Entries stored by GetOrPut with stampede protection are
+// not attributed to the actor.
+//
+//	ac := c.WithContext(redis.WithActor(req.Context(), userID))
+//	ac.Put("profile-"+userID, profile, time.Hour)
+func (r *redisCache) WithContext(ctx context.Context) cache.Cache {
+	return &actorCache{r: r, ctx: ctx}
+}
+
+// actorCache struct is the view of the Redis cache with the context of the
+// caller, i.e. the actor of the mutations and the parent span.
+type actorCache struct {
+	r   *redisCache
+	ctx context.Context
+}
+
+var _ cache.Cache = (*actorCache)(nil)
+
+func (a *actorCache) Name() string {
+	return a.r.Name()
+}
+
+func (a *actorCache) Get(k string) interface{} {
+	return a.r.getOrLoad(a.ctx, k)
+}
+
+func (a *actorCache) GetOrPut(k string, v interface{}, d time.Duration) (interface{}, error) {
+	ev, _, err := a.r.getOrPut(a.ctx, k, v, d)
+	return ev, err
+}
+
+func (a *actorCache) Put(k string, v interface{}, d time.Duration) error {
+	return a.r.putBy(a.ctx, k, v, d)
+}
+
+func (a *actorCache) Delete(k string) error {
+	return a.r.delete(a.ctx, k)
+}
+
+func (a *actorCache) Exists(k string) bool {
+	return a.r.exists(a.ctx, k)
+}
+
+func (a *actorCache) Flush() error {
+	return a.r.flush(a.ctx)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisAuditTrail(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			audit {
+				enable = true
+				stream = "aah:cache:audit:test"
+			}
+		}
+	}
+`, &cache.Config{Name: "auditcache", ProviderName: "redis1"}).(Cache)
+	r := c.(*redisCache)
+	assert.Nil(t, r.client().Del("aah:cache:audit:test").Err())
+
+	ac := c.WithContext(WithActor(context.Background(), "user:42"))
+	assert.Nil(t, ac.Put("audit-key1", "value1", time.Minute))
+	assert.Equal(t, "value1", ac.Get("audit-key1"))
+	v, err := ac.GetOrPut("audit-key2", "value2", time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, "value2", v)
+	assert.Nil(t, ac.Delete("audit-key1"))
+	assert.Nil(t, c.Put("audit-key3", "value3", time.Minute))
+	assert.Nil(t, ac.Flush())
+
+	msgs, err := r.client().XRangeN("aah:cache:audit:test", "-", "+", 10).Result()
+	assert.Nil(t, err)
+	assert.Len(t, msgs, 5)
+	expected := [][3]string{
+		{opPut, "audit-key1", "user:42"},
+		{opPut, "audit-key2", "user:42"},
+		{opDelete, "audit-key1", "user:42"},
+		{opPut, "audit-key3", ""},
+		{opFlush, "", "user:42"},
+	}
+	for i, m := range msgs {
+		assert.Equal(t, expected[i][0], m.Values["op"])
+		assert.Equal(t, expected[i][1], m.Values["key"])
+		assert.Equal(t, expected[i][2], m.Values["actor"])
+		assert.Equal(t, r.p.id, m.Values["instance"])
+	}
+}
+
+func TestRedisAuditActorContext(t *testing.T) {
+	assert.Equal(t, "", ActorFromContext(context.Background()))
+	assert.Equal(t, "svc-billing", ActorFromContext(WithActor(context.Background(), "svc-billing")))
+}
+
+func TestRedisAuditTarget(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			audit {
+				enable = true
+				target = "file"
+			}
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "auditcache2", ProviderName: "redis1"})
+	assert.Equal(t, "aah/cache/auditcache2: unsupported audit.target 'file'", err.Error())
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+
+	"github.com/go-redis/redis"
+)
+
+// CredentialsProvider func type returns the username and password used to
+// authenticate the Redis connection. It's invoked on every new connection,
+// so that rotating auth tokens (e.g. ElastiCache IAM auth, Azure AAD tokens)
+// are supported.
+type CredentialsProvider func() (username, password string, err error)
+
+// SetCredentialsProvider method sets the credentials provider of the Redis
+// connection, it takes precedence over `username` and `password` config. It
+// applies to every connection the provider creates, including the ring and
+// cluster nodes, replicas, pub/sub subscriptions and the cache clients with
+// overridden connection settings. Set it before the provider gets
+// initialized.
+func (p *Provider) SetCredentialsProvider(fn CredentialsProvider) {
+	p.credentials = fn
+}
+
+// authOnConnect method authenticates the new connection using Redis 6 ACL
+// `AUTH username password` command and then selects the DB, since go-redis
+// selects DB prior to the `OnConnect` callback.
+func (p *Provider) authOnConnect(cs connSettings, db int) func(*redis.Conn) error {
+	return func(conn *redis.Conn) error {
+		username, password := cs.username, cs.password
+		if p.credentials != nil {
+			var err error
+			if username, password, err = p.credentials(); err != nil {
+				return fmt.Errorf("credentials %v", err)
+			}
+		}
+
+		if len(username) > 0 || len(password) > 0 {
+			args := []interface{}{"auth"}
+			if len(username) > 0 {
+				args = append(args, username)
+			}
+			cmd := redis.NewStatusCmd(append(args, password)...)
+			_ = conn.Process(cmd)
+			if err := cmd.Err(); err != nil {
+				return err
+			}
+		}
+
+		if db > 0 {
+			return conn.Select(db).Err()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"io/ioutil"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisCredentialsProvider(t *testing.T) {
+	var calls int32
+	p := new(Provider)
+	p.SetCredentialsProvider(func() (string, string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "", "", nil
+	})
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			db = 1
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.True(t, atomic.LoadInt32(&calls) > 0)
+	assert.Equal(t, 0, p.clientOpts.DB)
+	assert.NotNil(t, p.clientOpts.OnConnect)
+
+	err := mgr.CreateCache(&cache.Config{Name: "authcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("authcache")
+	assert.Nil(t, c.Put("auth-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("auth-key1"))
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisCredentialsProviderError(t *testing.T) {
+	p := new(Provider)
+	p.SetCredentialsProvider(func() (string, string, error) {
+		return "", "", errors.New("token expired")
+	})
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Equal(t, errors.New("aah/cache/redis1: credentials token expired"), mgr.InitProviders(cfg, l))
+}
+
+func TestRedisCredentialsProviderCacheClient(t *testing.T) {
+	var calls int32
+	p := new(Provider)
+	p.SetCredentialsProvider(func() (string, string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "", "", nil
+	})
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				authdbcache {
+					db = 2
+				}
+			}
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	before := atomic.LoadInt32(&calls)
+
+	err := mgr.CreateCache(&cache.Config{Name: "authdbcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("authdbcache").(*redisCache)
+	assert.Nil(t, c.Put("auth-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("auth-key1"))
+	assert.True(t, atomic.LoadInt32(&calls) > before)
+	assert.False(t, c.cref == p.cref)
+	assert.Nil(t, c.Flush())
+}
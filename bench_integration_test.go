@@ -0,0 +1,105 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package redis
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+)
+
+// Integration benchmarks run against the Redis of env `REDIS_ADDR`, default
+// is `localhost:6379`, e.g.
+//
+//	docker run -d --rm -p 6379:6379 redis:7
+//	go test -tags integration -run '^$' -bench Integration -benchmem
+//
+// Each benchmark reports ops/s in addition to ns/op and allocations, so the
+// results of the performance-oriented changes could be compared with
+// benchstat.
+
+func BenchmarkIntegrationPut(b *testing.B) {
+	benchmarkIntegration(b, func(b *testing.B, c *redisCache, v benchPayload) {
+		for i := 0; i < b.N; i++ {
+			if err := c.Put("bench-key-"+strconv.Itoa(i%1000), v, time.Minute); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkIntegrationGet(b *testing.B) {
+	benchmarkIntegration(b, func(b *testing.B, c *redisCache, v benchPayload) {
+		for i := 0; i < b.N; i++ {
+			if c.Get("bench-key") == nil {
+				b.Fatal("cache miss")
+			}
+		}
+	})
+}
+
+func BenchmarkIntegrationGetParallel(b *testing.B) {
+	benchmarkIntegration(b, func(b *testing.B, c *redisCache, v benchPayload) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if c.Get("bench-key") == nil {
+					b.Fatal("cache miss")
+				}
+			}
+		})
+	})
+}
+
+func benchmarkIntegration(b *testing.B, fn func(b *testing.B, c *redisCache, v benchPayload)) {
+	registerType(reflect.TypeOf(benchPayload{}))
+	addr := os.Getenv("REDIS_ADDR")
+	if len(addr) == 0 {
+		addr = "localhost:6379"
+	}
+	mgr := createCacheMgr(b, "redis1", fmt.Sprintf(`
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "%s"
+		}
+	}
+`, addr))
+	p := mgr.Provider("redis1").(*Provider)
+	defer p.Close()
+
+	for _, s := range benchSettings {
+		for _, size := range benchSizes {
+			name := fmt.Sprintf("%s/%d", s.name, size)
+			if err := mgr.CreateCache(&cache.Config{Name: "bench-" + s.name + "-" + strconv.Itoa(size), ProviderName: "redis1"}); err != nil {
+				b.Fatal(err)
+			}
+			c := mgr.Cache("bench-" + s.name + "-" + strconv.Itoa(size)).(*redisCache)
+			s.apply(c)
+			v := newBenchPayload(size)
+			if err := c.Put("bench-key", v, time.Minute); err != nil {
+				b.Fatal(err)
+			}
+			b.Run(name, func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				start := time.Now()
+				fn(b, c, v)
+				b.StopTimer()
+				b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "ops/s")
+			})
+			if err := c.Flush(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
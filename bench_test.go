@@ -0,0 +1,119 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+)
+
+// benchSizes are the value sizes of the benchmarks.
+var benchSizes = []int{64, 1 << 10, 16 << 10}
+
+// benchPayload is the struct value of the benchmarks, Data is sized per
+// benchmark.
+type benchPayload struct {
+	ID   int
+	Name string
+	Tags []string
+	Data string
+}
+
+// benchSetting is the encoding setting of the cache under benchmark.
+type benchSetting struct {
+	name  string
+	apply func(r *redisCache)
+}
+
+// benchSettings are the encoding settings of the benchmarks, compression
+// applies to the values exceeding 512 bytes.
+var benchSettings = []benchSetting{
+	{name: "gob", apply: func(r *redisCache) {}},
+	{name: "json", apply: func(r *redisCache) { r.layout = layoutJSON }},
+	{name: "codec", apply: func(r *redisCache) { _ = r.RegisterCodec("bench", benchPayload{}, benchCodec{}) }},
+	{name: "compress", apply: func(r *redisCache) { r.maxValueSize, r.oversizePolicy = 512, oversizeCompress }},
+}
+
+// newBenchPayload method returns the payload of given size, Data is
+// compressible same as the typical cached text.
+func newBenchPayload(size int) benchPayload {
+	return benchPayload{
+		ID:   42,
+		Name: "aah",
+		Tags: []string{"cache", "redis"},
+		Data: strings.Repeat("aah-cache-", size/10+1)[:size],
+	}
+}
+
+func newBenchCache(s benchSetting) *redisCache {
+	r := &redisCache{cfg: &cache.Config{Name: "benchcache"}, layout: layoutNative}
+	s.apply(r)
+	return r
+}
+
+func BenchmarkEncode(b *testing.B) {
+	registerType(reflect.TypeOf(benchPayload{}))
+	for _, s := range benchSettings {
+		for _, size := range benchSizes {
+			b.Run(fmt.Sprintf("%s/%d", s.name, size), func(b *testing.B) {
+				r := newBenchCache(s)
+				e := &entry{D: time.Minute, V: newBenchPayload(size)}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					buf := acquireBuffer()
+					if err := r.encode("bench-key", buf, e); err != nil {
+						b.Fatal(err)
+					}
+					releaseBuffer(buf)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	registerType(reflect.TypeOf(benchPayload{}))
+	for _, s := range benchSettings {
+		for _, size := range benchSizes {
+			b.Run(fmt.Sprintf("%s/%d", s.name, size), func(b *testing.B) {
+				r := newBenchCache(s)
+				buf := new(bytes.Buffer)
+				if err := r.encode("bench-key", buf, &entry{D: time.Minute, V: newBenchPayload(size)}); err != nil {
+					b.Fatal(err)
+				}
+				data := buf.Bytes()
+				b.SetBytes(int64(len(data)))
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					var e entry
+					if err := r.decode("bench-key", data, &e); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+type benchCodec struct{}
+
+func (benchCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (benchCodec) Unmarshal(b []byte) (interface{}, error) {
+	var v benchPayload
+	err := json.Unmarshal(b, &v)
+	return v, err
+}
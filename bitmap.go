@@ -0,0 +1,82 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+
+	"github.com/go-redis/redis"
+)
+
+// SetBit method sets or clears the bit at offset in the bitmap of the cache
+// for given key and returns its previous value. Bitmap is created without
+// expiration if it does not exists, use `Expire` to set it. Bitmap keys share
+// the cache key prefix, they are removed by `Flush` and are not readable using
+// `Get`.
+//
+//	// daily active users by user ID
+//	_, err := c.SetBit("active-2018-10-16", userID, true)
+//	n, err := c.BitCount("active-2018-10-16")
+func (r *redisCache) SetBit(k string, offset int64, value bool) (bool, error) {
+	start := r.begin(opPut, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opPut, k, ErrCircuitOpen, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	bit := 0
+	if value {
+		bit = 1
+	}
+	n, err := r.client().SetBit(r.key(k), offset, bit).Result()
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opPut, k, resultOK, start)
+	return n == 1, nil
+}
+
+// GetBit method returns the bit at offset in the bitmap of the cache for given
+// key. Bits of the absent bitmap and beyond its length are false.
+func (r *redisCache) GetBit(k string, offset int64) (bool, error) {
+	n, err := r.bitmapRead(k, func(c Commander) *redis.IntCmd {
+		return c.GetBit(r.key(k), offset)
+	})
+	return n == 1, err
+}
+
+// BitCount method returns the number of set bits in the bitmap of the cache
+// for given key, it returns zero if the bitmap does not exists.
+func (r *redisCache) BitCount(k string) (int64, error) {
+	return r.bitmapRead(k, func(c Commander) *redis.IntCmd {
+		return c.BitCount(r.key(k), nil)
+	})
+}
+
+func (r *redisCache) bitmapRead(k string, fn func(c Commander) *redis.IntCmd) (int64, error) {
+	start := r.begin(opGet, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opGet, k, ErrCircuitOpen, start)
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	var n int64
+	err := r.read(func(c Commander) error {
+		var err error
+		n, err = fn(c).Result()
+		return err
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opGet, k, resultOK, start)
+	return n, nil
+}
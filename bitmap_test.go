@@ -0,0 +1,51 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisBitmap(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "bitmapcache", ProviderName: "redis1"}).(Cache)
+
+	for _, id := range []int64{1, 7, 100} {
+		prev, err := c.SetBit("active-day1", id, true)
+		assert.Nil(t, err)
+		assert.False(t, prev)
+	}
+	prev, err := c.SetBit("active-day1", 7, false)
+	assert.Nil(t, err)
+	assert.True(t, prev)
+
+	on, err := c.GetBit("active-day1", 100)
+	assert.Nil(t, err)
+	assert.True(t, on)
+	on, err = c.GetBit("active-day1", 7)
+	assert.Nil(t, err)
+	assert.False(t, on)
+	on, err = c.GetBit("not-exists", 1)
+	assert.Nil(t, err)
+	assert.False(t, on)
+
+	n, err := c.BitCount("active-day1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+	n, err = c.BitCount("not-exists")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	assert.Nil(t, c.Flush())
+}
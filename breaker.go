@@ -0,0 +1,97 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"aahframe.work/log"
+)
+
+// ErrCircuitOpen returned by the cache operations while the circuit breaker
+// is open, i.e. Redis is considered unavailable.
+var ErrCircuitOpen = errors.New("aah/cache: circuit breaker is open")
+
+const (
+	circuitClosed = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// breaker struct implements the circuit breaker of the Redis provider. It
+// trips open after `circuit_breaker.threshold` consecutive failures and the
+// operations fail fast (or served from the fallback memory cache) till
+// `circuit_breaker.cooldown` elapses. Then one trial operation is allowed,
+// on success circuit gets closed otherwise it's open again.
+//
+// It's enabled via config `circuit_breaker.enable = true`, fallback memory
+// cache via `circuit_breaker.fallback = "memory"`.
+type breaker struct {
+	mu        sync.Mutex
+	name      string
+	logger    log.Loggerer
+	threshold int
+	cooldown  time.Duration
+	state     int
+	failures  int
+	openedAt  time.Time
+}
+
+// allow method reports whether the operation is allowed to reach Redis. It's
+// safe to call on nil breaker.
+func (b *breaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitOpen, circuitHalfOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state, b.openedAt = circuitHalfOpen, time.Now()
+	}
+	return true
+}
+
+// done method records the outcome of the Redis operation. It's safe to call on
+// nil breaker.
+func (b *breaker) done(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		if b.state != circuitClosed {
+			b.logger.Infof("aah/cache/provider: %s circuit breaker closed, Redis is available", b.name)
+		}
+		b.state, b.failures = circuitClosed, 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || (b.state == circuitClosed && b.failures >= b.threshold) {
+		if b.state == circuitClosed {
+			b.logger.Errorf("aah/cache/provider: %s circuit breaker open after %d failures: %v", b.name, b.failures, err)
+		}
+		b.state, b.openedAt = circuitOpen, time.Now()
+	}
+}
+
+// circuitOpen method reports whether the cache operation should not reach
+// Redis. Fallback cache entries are discarded once Redis is reachable again.
+func (r *redisCache) circuitOpen() bool {
+	if !r.p.cb.allow() {
+		return true
+	}
+	if r.fallback != nil && r.fallback.Len() > 0 {
+		r.fallback.Flush()
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	b := &breaker{name: "redis1", logger: l, threshold: 2, cooldown: 50 * time.Millisecond}
+
+	assert.True(t, b.allow())
+	b.done(errors.New("failure 1"))
+	assert.True(t, b.allow())
+	b.done(errors.New("failure 2"))
+	assert.False(t, b.allow())
+
+	// half-open allows one trial after the cooldown
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, b.allow())
+	assert.False(t, b.allow())
+	b.done(errors.New("failure 3"))
+	assert.False(t, b.allow())
+
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, b.allow())
+	b.done(nil)
+	assert.True(t, b.allow())
+	assert.Equal(t, circuitClosed, b.state)
+
+	// nil breaker allows always
+	var nb *breaker
+	assert.True(t, nb.allow())
+	nb.done(errors.New("failure"))
+}
+
+func TestRedisCircuitBreakerFallback(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6390"
+			timeout {
+				connect = "50ms"
+			}
+			connect {
+				lazy = true
+			}
+			circuit_breaker {
+				enable = true
+				threshold = 2
+				cooldown = "1m"
+				fallback = "memory"
+			}
+		}
+	}
+`, &cache.Config{Name: "cbcache", ProviderName: "redis1"})
+	r := c.(*redisCache)
+	assert.NotNil(t, r.fallback)
+
+	assert.NotNil(t, c.Put("cb-key1", "value1", 3*time.Second))
+	assert.NotNil(t, c.Put("cb-key1", "value1", 3*time.Second))
+
+	// circuit is open, served from fallback memory cache
+	start := time.Now()
+	assert.Nil(t, c.Put("cb-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("cb-key1"))
+	assert.True(t, c.Exists("cb-key1"))
+	assert.True(t, time.Since(start) < 50*time.Millisecond)
+
+	err := c.Delete("cb-key1")
+	assert.Equal(t, errors.New("aah/cache/cbcache: key(cb-key1) aah/cache: circuit breaker is open"), err)
+	assert.Nil(t, c.Get("cb-key1"))
+}
@@ -0,0 +1,178 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// revisionKind is the entry header kind of the entry stored by PutIfVersion,
+// header `0x00<kind><duration>:<revision>:` wraps the encoded entry, duration
+// is kept in the header for the Lua scripts.
+const revisionKind = 0xf9
+
+// casScript sets the entry only if the version of the stored entry matches
+// ARGV[1], empty version matches the absent entry. Version is the revision of
+// the entry stored by this script, otherwise SHA1 of the entry. Entry is
+// stored with the next revision, it's seeded with Redis server time in
+// microseconds so the revision keeps increasing across Delete and re-create.
+var casScript = redis.NewScript(`redis.replicate_commands()
+local v = redis.call("get", KEYS[1])
+local ver, rev = "", 0
+if v then
+	if string.byte(v, 1) == 0 and string.byte(v, 2) == tonumber(ARGV[4]) then
+		ver = string.match(v, "^..%-?%d+:(%d+):")
+		rev = tonumber(ver)
+	else
+		ver = redis.sha1hex(v)
+	end
+end
+if ver ~= ARGV[1] then
+	return 0
+end
+local t = redis.call("time")
+local nrev = tonumber(t[1]) * 1000000 + tonumber(t[2])
+if nrev <= rev then
+	nrev = rev + 1
+end
+v = string.char(0, tonumber(ARGV[4])) .. ARGV[5] .. ":" .. string.format("%.0f", nrev) .. ":" .. ARGV[2]
+if tonumber(ARGV[3]) > 0 then
+	redis.call("set", KEYS[1], v, "px", ARGV[3])
+else
+	redis.call("set", KEYS[1], v)
+end
+return 1`)
+
+// revision function returns the revision and the wrapped entry of the entry
+// stored by PutIfVersion.
+func revision(b []byte) (string, []byte, bool) {
+	if len(b) < 2 || b[0] != rawMarker || b[1] != revisionKind {
+		return "", nil, false
+	}
+	idx := bytes.IndexByte(b, ':')
+	if idx < 2 {
+		return "", nil, false
+	}
+	ridx := bytes.IndexByte(b[idx+1:], ':')
+	if ridx < 1 {
+		return "", nil, false
+	}
+	return string(b[idx+1 : idx+1+ridx]), b[idx+2+ridx:], true
+}
+
+// entryVersion function returns the version token of the stored entry, see
+// `casScript`.
+func entryVersion(b []byte) string {
+	if rev, _, found := revision(b); found {
+		return rev
+	}
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// GetWithVersion method returns the cached entry and its version token for
+// given key. It returns `ErrCacheMiss` if the entry does not exists. Version
+// token is used with `PutIfVersion` for optimistic concurrency, value is read
+// from Redis bypassing the local cache layer. Token of the entry stored by
+// `PutIfVersion` is its monotonic revision, so the entry changed and restored
+// to the same value does not match the stale token. Entry stored by other
+// methods, e.g. Put, has SHA1 of the stored entry as token.
+//
+//	v, ver, err := c.GetWithVersion("counter")
+//	// modify the value
+//	stored, err := c.PutIfVersion("counter", nv, ver, time.Hour)
+func (r *redisCache) GetWithVersion(k string) (interface{}, string, error) {
+	start := r.begin(opGet, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opGet, k, ErrCircuitOpen, start)
+		return nil, "", fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	b, err := r.client().Get(r.key(k)).Bytes()
+	r.p.done(notacacheMiss(err))
+	if err != nil {
+		r.stats.miss()
+		if err = notacacheMiss(err); err == nil {
+			r.observe(opGet, k, resultMiss, start)
+			return nil, "", ErrCacheMiss
+		}
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return nil, "", fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+
+	var e entry
+	if err = r.decode(k, b, &e); err != nil {
+		r.stats.error()
+		r.stats.miss()
+		r.observeError(opGet, k, err, start)
+		return nil, "", fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.stats.hit()
+	r.observe(opGet, k, resultHit, start)
+	return e.V, entryVersion(b), nil
+}
+
+// PutIfVersion method puts the cache entry with specified expiration only if
+// the stored entry version matches the given version, it's checked and set
+// atomically using Lua script. Empty version means the entry must not exists.
+// It returns false if the entry is changed by others since it's read. Queued
+// write-behind entries of the key are cancelled. Entry is stored with the
+// revision header, Append and SetRange return `ErrNotString` for it.
+func (r *redisCache) PutIfVersion(k string, v interface{}, version string, d time.Duration) (bool, error) {
+	start := r.begin(opPut, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opPut, k, ErrCircuitOpen, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	if r.wb != nil {
+		r.wb.cancel(k)
+	}
+	registerType(reflect.TypeOf(v))
+	d = r.expiration(k, d)
+	e := &entry{D: d, V: v}
+	if d > 0 {
+		e.E = start.Add(d)
+	}
+
+	buf := acquireBuffer()
+	if err := r.encode(k, buf, e); err != nil {
+		releaseBuffer(buf)
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	n, err := casScript.Run(r.client(), []string{r.key(k)}, version, buf.Bytes(), durationMillis(d),
+		revisionKind, int64(d)).Int64()
+	releaseBuffer(buf)
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opPut, k, resultOK, start)
+	if n == 0 {
+		return false, nil
+	}
+	if r.local != nil {
+		r.local.Put(k, v, d)
+	}
+	if r.inv != nil {
+		r.inv.publish(k)
+	}
+	r.emit(opPut, k)
+	r.metaPut(k, d)
+	r.stats.put()
+	return true, nil
+}
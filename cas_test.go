@@ -0,0 +1,159 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisCompareAndSwap(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "cascache", ProviderName: "redis1"}).(Cache)
+
+	_, _, err := c.GetWithVersion("counter")
+	assert.Equal(t, ErrCacheMiss, err)
+
+	// empty version puts only if absent
+	stored, err := c.PutIfVersion("counter", 1, "", 10*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, stored)
+	stored, err = c.PutIfVersion("counter", 1, "", 10*time.Second)
+	assert.Nil(t, err)
+	assert.False(t, stored)
+
+	v, ver, err := c.GetWithVersion("counter")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, v)
+	assert.NotEmpty(t, ver)
+
+	stored, err = c.PutIfVersion("counter", 2, ver, 10*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, stored)
+
+	// stale version
+	stored, err = c.PutIfVersion("counter", 3, ver, 10*time.Second)
+	assert.Nil(t, err)
+	assert.False(t, stored)
+	assert.Equal(t, 2, c.Get("counter"))
+
+	d, err := c.TTL("counter")
+	assert.Nil(t, err)
+	assert.True(t, d > 9*time.Second)
+
+	// concurrent read-modify-write
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, ver, err := c.GetWithVersion("counter")
+				if err != nil {
+					return
+				}
+				if stored, _ := c.PutIfVersion("counter", v.(int)+1, ver, 10*time.Second); stored {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 12, c.Get("counter"))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisCompareAndSwapRevision(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "casrevcache", ProviderName: "redis1"}).(Cache)
+
+	// entry stored by Put has SHA1 token
+	assert.Nil(t, c.Put("key1", "a", 10*time.Second))
+	_, ver, err := c.GetWithVersion("key1")
+	assert.Nil(t, err)
+	assert.Len(t, ver, 40)
+	stored, err := c.PutIfVersion("key1", "a", ver, 10*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, stored)
+
+	// changed and restored entry does not match the stale token
+	_, stale, err := c.GetWithVersion("key1")
+	assert.Nil(t, err)
+	_, ver, _ = c.GetWithVersion("key1")
+	stored, _ = c.PutIfVersion("key1", "b", ver, 10*time.Second)
+	assert.True(t, stored)
+	_, ver, _ = c.GetWithVersion("key1")
+	stored, _ = c.PutIfVersion("key1", "a", ver, 10*time.Second)
+	assert.True(t, stored)
+	_, ver, _ = c.GetWithVersion("key1")
+	assert.True(t, ver > stale)
+	stored, err = c.PutIfVersion("key1", "c", stale, 10*time.Second)
+	assert.Nil(t, err)
+	assert.False(t, stored)
+	assert.Equal(t, "a", c.Get("key1"))
+
+	// revision keeps increasing across Delete and re-create
+	assert.Nil(t, c.Delete("key1"))
+	stored, _ = c.PutIfVersion("key1", "a", "", 10*time.Second)
+	assert.True(t, stored)
+	_, recreated, _ := c.GetWithVersion("key1")
+	assert.True(t, recreated > ver)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisCompareAndSwapWriteBehind(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			write_behind {
+				enable = true
+				flush_interval = "1h"
+				batch_size = 1000
+			}
+		}
+	}
+`, &cache.Config{Name: "caswbcache", ProviderName: "redis1"}).(*redisCache)
+
+	// queued Put is not written over PutIfVersion, Swap and Take
+	assert.Nil(t, c.Put("key1", "queued", 10*time.Second))
+	stored, err := c.PutIfVersion("key1", "cas", "", 10*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, stored)
+	assert.Nil(t, c.Put("key2", "queued", 10*time.Second))
+	_, err = c.Swap("key2", "swapped", 10*time.Second)
+	assert.Nil(t, err)
+	assert.Nil(t, c.client().Set(c.key("key3"), "\x00\x180:stored", 0).Err())
+	assert.Nil(t, c.Put("key3", "queued", 10*time.Second))
+	v, err := c.Take("key3")
+	assert.Nil(t, err)
+	assert.Equal(t, "stored", v)
+
+	c.wb.close()
+	assert.Equal(t, "cas", c.Get("key1"))
+	assert.Equal(t, "swapped", c.Get("key2"))
+	assert.False(t, c.Exists("key3"))
+
+	assert.Nil(t, c.Flush())
+}
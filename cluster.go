@@ -0,0 +1,94 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+
+	"github.com/go-redis/redis"
+)
+
+// newCluster method creates the Redis Cluster client for config
+// `mode = "cluster"`. Cluster nodes and slots are discovered from the seed
+// nodes of config `addresses` or the single configuration endpoint `address`,
+// e.g. AWS ElastiCache cluster mode configuration endpoint. Connection
+// settings are the same as standalone mode, `db` must be 0.
+//
+// With TLS, nodes discovered by IP address are verified against the host
+// name of the first seed node, since ElastiCache node certificates are
+// issued for the cluster domain. It could be changed via `tls.server_name`.
+// Replicas serve the reads with `cluster.read_from_replicas = true`.
+//
+//	mode = "cluster"
+//	address = "clustercfg.my-cache.abc123.use1.cache.amazonaws.com:6379"
+//	password = "<auth token>"
+//	tls.enable = true
+func (p *Provider) newCluster(opts *redis.Options) (*redis.ClusterClient, error) {
+	if opts.DB != 0 {
+		return nil, errors.New("cluster mode supports only db 0")
+	}
+	addresses, found := p.appCfg.StringList(p.cfgPrefix + "addresses")
+	if !found || len(addresses) == 0 {
+		addresses = []string{opts.Addr}
+	}
+
+	tlsConfig, err := clusterTLSConfig(opts.TLSConfig, addresses[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := redis.NewClusterClient(&redis.ClusterOptions{
+		Addrs:              addresses,
+		ReadOnly:           p.appCfg.BoolDefault(p.cfgPrefix+"cluster.read_from_replicas", false),
+		RouteByLatency:     p.appCfg.BoolDefault(p.cfgPrefix+"cluster.route_by_latency", false),
+		MaxRedirects:       p.appCfg.IntDefault(p.cfgPrefix+"cluster.max_redirects", 8),
+		OnConnect:          opts.OnConnect,
+		Password:           opts.Password,
+		MinRetryBackoff:    opts.MinRetryBackoff,
+		MaxRetryBackoff:    opts.MaxRetryBackoff,
+		DialTimeout:        opts.DialTimeout,
+		ReadTimeout:        opts.ReadTimeout,
+		WriteTimeout:       opts.WriteTimeout,
+		PoolSize:           opts.PoolSize,
+		PoolTimeout:        opts.PoolTimeout,
+		MinIdleConns:       opts.MinIdleConns,
+		MaxConnAge:         opts.MaxConnAge,
+		IdleTimeout:        opts.IdleTimeout,
+		IdleCheckFrequency: opts.IdleCheckFrequency,
+		TLSConfig:          tlsConfig,
+	})
+	p.wrapRetry(cluster, opts.MaxRetries, opts.MinRetryBackoff, opts.MaxRetryBackoff)
+	return cluster, nil
+}
+
+// clusterTLSConfig returns the TLS config with server name of the seed node
+// address if it's not set.
+func clusterTLSConfig(tlsConfig *tls.Config, seed string) (*tls.Config, error) {
+	if tlsConfig == nil || len(tlsConfig.ServerName) > 0 {
+		return tlsConfig, nil
+	}
+	host, _, err := net.SplitHostPort(seed)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.ServerName = host
+	return tlsConfig, nil
+}
+
+// newTLSConfig method creates the TLS config from config `tls.enable`,
+// `tls.server_name` and `tls.insecure_skip_verify`. It returns nil if TLS is
+// not enabled.
+func (p *Provider) newTLSConfig() *tls.Config {
+	if !p.appCfg.BoolDefault(p.cfgPrefix+"tls.enable", false) {
+		return nil
+	}
+	return &tls.Config{
+		ServerName:         p.appCfg.StringDefault(p.cfgPrefix+"tls.server_name", ""),
+		InsecureSkipVerify: p.appCfg.BoolDefault(p.cfgPrefix+"tls.insecure_skip_verify", false),
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"testing"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisClusterMode(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			mode = "cluster"
+			address = "localhost:7000"
+			tls {
+				enable = true
+			}
+			connect {
+				lazy = true
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	_, ok := p.UniversalClient().(*redis.ClusterClient)
+	assert.True(t, ok)
+	assert.Nil(t, p.Client())
+	assert.Nil(t, p.clientOpts)
+	assert.Nil(t, p.Close())
+}
+
+func TestRedisClusterModeDB(t *testing.T) {
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", new(Provider))
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			mode = "cluster"
+			address = "localhost:7000"
+			db = 1
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	err := mgr.InitProviders(cfg, l)
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/redis1: cluster mode supports only db 0", err.Error())
+}
+
+func TestRedisClusterTLSConfig(t *testing.T) {
+	tc, err := clusterTLSConfig(nil, "localhost:7000")
+	assert.Nil(t, err)
+	assert.Nil(t, tc)
+
+	orig := &tls.Config{}
+	tc, err = clusterTLSConfig(orig, "clustercfg.my-cache.abc123.use1.cache.amazonaws.com:6379")
+	assert.Nil(t, err)
+	assert.Equal(t, "clustercfg.my-cache.abc123.use1.cache.amazonaws.com", tc.ServerName)
+	assert.Equal(t, "", orig.ServerName)
+
+	tc, err = clusterTLSConfig(&tls.Config{ServerName: "cache.internal"}, "10.0.0.1:6379")
+	assert.Nil(t, err)
+	assert.Equal(t, "cache.internal", tc.ServerName)
+
+	_, err = clusterTLSConfig(&tls.Config{}, "10.0.0.1")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"aahframe.work/cache"
+	"golang.org/x/sync/singleflight"
+)
+
+// coalescer struct deduplicates the concurrent reads of the same key within
+// the process, only one Redis GET is in-flight and its result is shared with
+// the waiting callers. Each caller decodes the shared entry, so the decoded
+// values are not shared. It's enabled per cache via config
+// `coalesce_gets = true`.
+type coalescer struct {
+	group singleflight.Group
+}
+
+// fetch method reads the encoded cache entry from Redis, concurrent reads of
+// the same key are coalesced if it's enabled.
+func (r *redisCache) fetch(k string) ([]byte, error) {
+	if r.co == nil {
+		return r.fetchEntry(k)
+	}
+	v, err, _ := r.co.group.Do(k, func() (interface{}, error) {
+		return r.fetchEntry(k)
+	})
+	b, _ := v.([]byte)
+	return b, err
+}
+
+func (r *redisCache) fetchEntry(k string) ([]byte, error) {
+	if r.cfg.EvictionMode == cache.EvictionModeSlide {
+		return r.getSlide(k)
+	}
+	var v []byte
+	err := r.read(func(c Commander) error {
+		var err error
+		v, err = c.Get(r.key(k)).Bytes()
+		return err
+	})
+	return v, err
+}
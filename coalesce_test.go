@@ -0,0 +1,62 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisCoalesceGets(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			coalesce_gets = true
+		}
+	}
+`, &cache.Config{Name: "coalescecache", ProviderName: "redis1"}).(Cache)
+	assert.Nil(t, c.Put("hot-key", []byte("value1"), time.Minute))
+
+	var gets int32
+	c.(*redisCache).client().(redis.UniversalClient).WrapProcess(func(process func(cmd redis.Cmder) error) func(cmd redis.Cmder) error {
+		return func(cmd redis.Cmder) error {
+			if cmd.Name() == "get" {
+				atomic.AddInt32(&gets, 1)
+				time.Sleep(20 * time.Millisecond)
+			}
+			return process(cmd)
+		}
+	})
+
+	var wg sync.WaitGroup
+	values := make([][]byte, 50)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = c.Get("hot-key").([]byte)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.True(t, atomic.LoadInt32(&gets) < 50)
+	for _, v := range values {
+		assert.Equal(t, []byte("value1"), v)
+	}
+
+	// decoded values are not shared
+	values[0][0] = 'V'
+	assert.Equal(t, []byte("value1"), values[1])
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// codecKind is the entry header kind of the entry encoded by the registered
+// codec.
+const codecKind = 0xfa
+
+// Codec interface marshals and unmarshals the values of the Go type it's
+// registered for via `RegisterCodec`, e.g. protobuf messages or decimal
+// types, instead of gob.
+type Codec interface {
+	// Marshal method returns the encoded bytes of v.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal method returns the value decoded from b.
+	Unmarshal(b []byte) (interface{}, error)
+}
+
+// TimeCodec is the codec of `time.Time` values in RFC 3339 format with
+// nanoseconds, it keeps the time zone offset of the value.
+//
+//	c.RegisterCodec("time", time.Time{}, redis.TimeCodec)
+var TimeCodec Codec = timeCodec{}
+
+// codecs struct holds the codecs registered for the cache by the Go type and
+// by the name stored in the entry header.
+type codecs struct {
+	byType map[reflect.Type]namedCodec
+	byName map[string]Codec
+}
+
+type namedCodec struct {
+	name string
+	c    Codec
+}
+
+// RegisterCodec method registers the codec for the Go type of v, values of the
+// type are stored using the codec instead of gob. Name of the codec is stored
+// in the entry header, so keep it stable and register the same name on the
+// apps sharing the cache. Codecs are registered per cache, so they have no
+// global side effects unlike `gob.Register`. Register the codecs before the
+// cache is being used.
+//
+//	err := c.RegisterCodec("order.v1", &pb.Order{}, protoCodec)
+func (r *redisCache) RegisterCodec(name string, v interface{}, c Codec) error {
+	if len(name) == 0 || strings.IndexByte(name, ':') >= 0 {
+		return fmt.Errorf("aah/cache/%s: invalid codec name '%s'", r.Name(), name)
+	}
+	t := reflect.TypeOf(v)
+	if t == nil || c == nil {
+		return fmt.Errorf("aah/cache/%s: codec(%s) non-nil value and codec expected", r.Name(), name)
+	}
+	if r.codecs == nil {
+		r.codecs = &codecs{byType: make(map[reflect.Type]namedCodec), byName: make(map[string]Codec)}
+	}
+	r.codecs.byType[t] = namedCodec{name: name, c: c}
+	r.codecs.byName[name] = c
+	return nil
+}
+
+// encodeValue method writes the cache entry into buf using the codec
+// registered for the value type, otherwise per the storage layout.
+func (r *redisCache) encodeValue(buf *bytes.Buffer, e *entry) error {
+	if r.codecs != nil {
+		if nc, found := r.codecs.byType[reflect.TypeOf(e.V)]; found {
+			b, err := nc.c.Marshal(e.V)
+			if err != nil {
+				return fmt.Errorf("codec(%s) %v", nc.name, err)
+			}
+			writeHeader(buf, codecKind, e.D)
+			buf.WriteString(nc.name)
+			buf.WriteByte(':')
+			buf.Write(b)
+			return nil
+		}
+	}
+	if r.layout == layoutJSON {
+		return encodeJSON(buf, e)
+	}
+	return encodeEntry(buf, e)
+}
+
+// decodeValue method reads the cache entry from b written by `encodeValue`.
+// Entry header of the codec is `0x00<kind><duration>:<codec name>:`.
+func (r *redisCache) decodeValue(b []byte, e *entry) error {
+	if len(b) < 2 || b[0] != rawMarker || b[1] != codecKind {
+		return decodeEntry(b, e)
+	}
+	idx := bytes.IndexByte(b, ':')
+	if idx < 2 {
+		return errInvalidRawEntry
+	}
+	d, ok := parseHeaderDuration(b[2:idx])
+	nidx := bytes.IndexByte(b[idx+1:], ':')
+	if !ok || nidx < 1 {
+		return errInvalidRawEntry
+	}
+	name := string(b[idx+1 : idx+1+nidx])
+	var c Codec
+	if r.codecs != nil {
+		c = r.codecs.byName[name]
+	}
+	if c == nil {
+		return fmt.Errorf("codec(%s) is not registered", name)
+	}
+	v, err := c.Unmarshal(b[idx+2+nidx:])
+	if err != nil {
+		return fmt.Errorf("codec(%s) %v", name, err)
+	}
+	e.D, e.V = d, v
+	return nil
+}
+
+type timeCodec struct{}
+
+func (timeCodec) Marshal(v interface{}) ([]byte, error) {
+	t, ok := v.(time.Time)
+	if !ok {
+		return nil, errors.New("time.Time value expected")
+	}
+	return []byte(t.Format(time.RFC3339Nano)), nil
+}
+
+func (timeCodec) Unmarshal(b []byte) (interface{}, error) {
+	return time.Parse(time.RFC3339Nano, string(b))
+}
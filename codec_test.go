@@ -0,0 +1,61 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cents int64
+
+type centsCodec struct{}
+
+func (centsCodec) Marshal(v interface{}) ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(v.(cents)), 10) + "c"), nil
+}
+
+func (centsCodec) Unmarshal(b []byte) (interface{}, error) {
+	n, err := strconv.ParseInt(string(bytes.TrimSuffix(b, []byte("c"))), 10, 64)
+	return cents(n), err
+}
+
+func TestEntryCodec(t *testing.T) {
+	r := &redisCache{}
+	assert.Nil(t, r.RegisterCodec("cents", cents(0), centsCodec{}))
+	assert.Nil(t, r.RegisterCodec("time", time.Time{}, TimeCodec))
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, r.encode("price", buf, &entry{D: time.Second, V: cents(1250)}))
+	assert.Equal(t, append([]byte{rawMarker, codecKind}, "1000000000:cents:1250c"...), buf.Bytes())
+	var e entry
+	assert.Nil(t, r.decode("price", buf.Bytes(), &e))
+	assert.Equal(t, cents(1250), e.V)
+	assert.Equal(t, time.Second, e.D)
+
+	now := time.Date(2018, 10, 16, 10, 30, 0, 123, time.FixedZone("IST", 19800))
+	buf.Reset()
+	assert.Nil(t, r.encode("now", buf, &entry{V: now}))
+	e = entry{}
+	assert.Nil(t, r.decode("now", buf.Bytes(), &e))
+	assert.True(t, now.Equal(e.V.(time.Time)))
+	_, offset := e.V.(time.Time).Zone()
+	assert.Equal(t, 19800, offset)
+
+	// other types are not affected
+	buf.Reset()
+	assert.Nil(t, r.encode("name", buf, &entry{V: "aah"}))
+	e = entry{}
+	assert.Nil(t, r.decode("name", buf.Bytes(), &e))
+	assert.Equal(t, "aah", e.V)
+
+	// codec is per cache
+	other := &redisCache{}
+	assert.NotNil(t, other.decode("price", append([]byte{rawMarker, codecKind}, "0:cents:1c"...), &e))
+}
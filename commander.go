@@ -0,0 +1,89 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// ProviderWithCommander method returns the Redis cache provider which executes
+// the Redis commands using given Commander instead of the go-redis client. So
+// that the unit tests of the application could mock the cache without running
+// Redis or miniredis. Embed the `Commander` interface in the mock and implement
+// only the commands the code under test needs, e.g. Ping, Get, Set and Del for
+// Get, Put and Delete. Use `redis.NewStatusResult`, `redis.NewStringResult`,
+// etc. to build the command results.
+//
+//	type mock struct {
+//		redis.Commander
+//		data map[string]string
+//	}
+//
+//	aah.App().CacheManager().AddProvider("redis1", redis.ProviderWithCommander(mock))
+func ProviderWithCommander(c Commander) *Provider {
+	return &Provider{cref: newClientRef(c)}
+}
+
+// Commander interface is the Redis command executor of the provider, it's
+// satisfied by the go-redis clients `*redis.Client`, `*redis.Ring` and
+// `*redis.ClusterClient`. It's the subset of the go-redis client methods used
+// by the provider.
+//
+// Stability: methods are added to the interface when the provider starts using
+// the command, so the mocks should embed the interface instead of implementing
+// all the methods, otherwise they break on the minor version upgrade. Methods
+// are not removed or changed within the major version.
+type Commander interface {
+	Pipelined(fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
+	TxPipelined(fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
+	Process(cmd redis.Cmder) error
+	Subscribe(channels ...string) *redis.PubSub
+	Publish(channel string, message interface{}) *redis.IntCmd
+	Close() error
+
+	Ping() *redis.StatusCmd
+	ConfigSet(parameter, value string) *redis.StatusCmd
+	Scan(cursor uint64, match string, count int64) *redis.ScanCmd
+	DBSize() *redis.IntCmd
+	FlushDB() *redis.StatusCmd
+	Get(key string) *redis.StringCmd
+	Set(key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	SetNX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd
+	SetXX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd
+	Del(keys ...string) *redis.IntCmd
+	Unlink(keys ...string) *redis.IntCmd
+	Exists(keys ...string) *redis.IntCmd
+	Expire(key string, expiration time.Duration) *redis.BoolCmd
+	PExpire(key string, expiration time.Duration) *redis.BoolCmd
+	Persist(key string) *redis.BoolCmd
+	TTL(key string) *redis.DurationCmd
+	PTTL(key string) *redis.DurationCmd
+	Incr(key string) *redis.IntCmd
+	SetBit(key string, offset int64, value int) *redis.IntCmd
+	GetBit(key string, offset int64) *redis.IntCmd
+	BitCount(key string, bitCount *redis.BitCount) *redis.IntCmd
+	HGet(key, field string) *redis.StringCmd
+	HGetAll(key string) *redis.StringStringMapCmd
+	PFCount(keys ...string) *redis.IntCmd
+	PFAdd(key string, els ...interface{}) *redis.IntCmd
+	LPush(key string, values ...interface{}) *redis.IntCmd
+	RPush(key string, values ...interface{}) *redis.IntCmd
+	LPop(key string) *redis.StringCmd
+	LRange(key string, start, stop int64) *redis.StringSliceCmd
+	BLPop(timeout time.Duration, keys ...string) *redis.StringSliceCmd
+	MemoryUsage(key string, samples ...int) *redis.IntCmd
+	XAdd(a *redis.XAddArgs) *redis.StringCmd
+	XRangeN(stream, start, stop string, count int64) *redis.XMessageSliceCmd
+	XReadGroup(a *redis.XReadGroupArgs) *redis.XStreamSliceCmd
+	XAck(stream, group string, ids ...string) *redis.IntCmd
+
+	// commands of the Lua scripts, see `redis.Script`
+	Eval(script string, keys []string, args ...interface{}) *redis.Cmd
+	EvalSha(sha1 string, keys []string, args ...interface{}) *redis.Cmd
+	ScriptExists(hashes ...string) *redis.BoolSliceCmd
+	ScriptLoad(script string) *redis.StringCmd
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis_test
+
+import (
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	cacheredis "aahframe.work/cache/provider/redis"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCommander implements the commands used by Get, Put and Delete on the
+// map, other commands panic. It's in the external test package, so that it
+// mocks the cache the same way as the application tests.
+type mockCommander struct {
+	cacheredis.Commander
+	mu sync.Mutex
+	m  map[string]string
+}
+
+func (mc *mockCommander) Ping() *redis.StatusCmd {
+	return redis.NewStatusResult("PONG", nil)
+}
+
+func (mc *mockCommander) Get(key string) *redis.StringCmd {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	v, found := mc.m[key]
+	if !found {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(v, nil)
+}
+
+func (mc *mockCommander) Set(key string, value interface{}, _ time.Duration) *redis.StatusCmd {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.m[key] = string(value.([]byte))
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (mc *mockCommander) Del(keys ...string) *redis.IntCmd {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	var n int64
+	for _, k := range keys {
+		if _, found := mc.m[k]; found {
+			delete(mc.m, k)
+			n++
+		}
+	}
+	return redis.NewIntResult(n, nil)
+}
+
+func (mc *mockCommander) Close() error { return nil }
+
+func TestRedisProviderWithCommander(t *testing.T) {
+	mc := &mockCommander{m: make(map[string]string)}
+	p := cacheredis.ProviderWithCommander(mc)
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.Nil(t, p.Client())
+	assert.Nil(t, p.UniversalClient())
+
+	err := mgr.CreateCache(&cache.Config{Name: "mockcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("mockcache")
+	assert.Nil(t, c.Put("mock-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("mock-key1"))
+	assert.Len(t, mc.m, 1)
+	assert.Nil(t, c.Delete("mock-key1"))
+	assert.Nil(t, c.Get("mock-key1"))
+	assert.Len(t, mc.m, 0)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Connected method reports whether the provider has established the
+// connection with Redis server.
+func (p *Provider) Connected() bool {
+	return atomic.LoadInt32(&p.connected) == 1
+}
+
+// connect method verifies the connectivity with Redis server. On failure the
+// connection is diagnosed, see `ConnectError`. With config
+// `connect.lazy = true`, provider starts in the degraded state and retries in
+// the background with backoff between `connect.retry_backoff.min` and
+// `connect.retry_backoff.max`, otherwise it returns the error.
+func (p *Provider) connect() error {
+	err := p.client().Ping().Err()
+	p.health.record(err)
+	if err == nil {
+		atomic.StoreInt32(&p.connected, 1)
+		return nil
+	}
+	err = p.diagnose(err)
+	if !p.appCfg.BoolDefault(p.cfgPrefix+"connect.lazy", false) {
+		return err
+	}
+
+	p.logger.Warnf("aah/cache/provider: %s unable to connect, retrying in the background: %v", p.name, err)
+	go p.reconnect(
+		parseDuration(p.appCfg.StringDefault(p.cfgPrefix+"connect.retry_backoff.min", "1s"), "1s"),
+		parseDuration(p.appCfg.StringDefault(p.cfgPrefix+"connect.retry_backoff.max", "30s"), "30s"),
+	)
+	return nil
+}
+
+func (p *Provider) reconnect(minBackoff, maxBackoff time.Duration) {
+	backoff := minBackoff
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-p.closing:
+			return
+		case <-time.After(backoff):
+		}
+		err := p.client().Ping().Err()
+		p.health.record(err)
+		if err == nil {
+			atomic.StoreInt32(&p.connected, 1)
+			p.logger.Infof("aah/cache/provider: %s connection restored after %d attempt(s)", p.name, attempt)
+			return
+		}
+		p.logger.Debugf("aah/cache/provider: %s reconnect attempt %d failed: %v", p.name, attempt, err)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Connection diagnostic stages of `ConnectError`.
+const (
+	StageDNS  = "dns"
+	StageTCP  = "tcp"
+	StageTLS  = "tls"
+	StageAuth = "auth"
+	StagePing = "ping"
+)
+
+// ConnectError struct describes why the provider could not connect to the
+// Redis server. When the initial PING fails, provider diagnoses the
+// connection step by step, i.e. DNS resolution, TCP connect, TLS handshake
+// and AUTH, and reports the first failed stage with the hint to fix it.
+//
+//	var cerr *redis.ConnectError
+//	if errors.As(err, &cerr) && cerr.Stage == redis.StageAuth {
+//		// wrong password
+//	}
+type ConnectError struct {
+	Stage string
+	Addr  string
+	Hint  string
+	Err   error
+}
+
+// Error method returns the diagnosed error message.
+func (e *ConnectError) Error() string {
+	return fmt.Sprintf("%s check failed for %s: %v; %s", e.Stage, e.Addr, e.Err, e.Hint)
+}
+
+// Unwrap method returns the underlying error.
+func (e *ConnectError) Unwrap() error {
+	return e.Err
+}
+
+// diagnose method runs the connection diagnostic against the configured
+// address when the PING fails and returns the `ConnectError`. Original error
+// is returned as-is for ring, cluster, embedded and unix socket connections.
+func (p *Provider) diagnose(err error) error {
+	opts := p.clientOpts
+	if opts == nil || p.embedded != nil || opts.Network != "tcp" {
+		return err
+	}
+	addr := opts.Addr
+	host, _, serr := net.SplitHostPort(addr)
+	if serr != nil {
+		return &ConnectError{Stage: StageDNS, Addr: addr, Err: serr,
+			Hint: "address must be in 'host:port' format"}
+	}
+	if len(host) > 0 && net.ParseIP(host) == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.DialTimeout)
+		_, lerr := net.DefaultResolver.LookupHost(ctx, host)
+		cancel()
+		if lerr != nil {
+			return &ConnectError{Stage: StageDNS, Addr: addr, Err: lerr,
+				Hint: "verify the host name of config 'address' and the DNS settings"}
+		}
+	}
+
+	conn, derr := net.DialTimeout("tcp", addr, opts.DialTimeout)
+	if derr != nil {
+		return &ConnectError{Stage: StageTCP, Addr: addr, Err: derr,
+			Hint: "verify the Redis server is running and the port is reachable, e.g. firewall, security group"}
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(opts.DialTimeout))
+
+	rw := conn
+	if opts.TLSConfig != nil {
+		tc := tls.Client(conn, opts.TLSConfig)
+		if herr := tc.Handshake(); herr != nil {
+			hint := "verify the server certificate and config 'tls.server_name'"
+			if _, ok := herr.(tls.RecordHeaderError); ok {
+				hint = "server does not speak TLS, set config 'tls.enable = false'"
+			}
+			return &ConnectError{Stage: StageTLS, Addr: addr, Err: herr, Hint: hint}
+		}
+		rw = tc
+	}
+	br := bufio.NewReader(rw)
+
+	username, password := p.username, opts.Password
+	if len(username) > 0 || p.credentials != nil {
+		password = p.password
+	}
+	if p.credentials != nil {
+		var cerr error
+		if username, password, cerr = p.credentials(); cerr != nil {
+			return &ConnectError{Stage: StageAuth, Addr: addr, Err: cerr,
+				Hint: "credentials provider failed"}
+		}
+	}
+	if len(username) > 0 || len(password) > 0 {
+		args := []string{"AUTH"}
+		if len(username) > 0 {
+			args = append(args, username)
+		}
+		reply, rerr := respCommand(rw, br, append(args, password)...)
+		if cerr := replyError(addr, opts.TLSConfig != nil, reply, rerr); cerr != nil {
+			return cerr
+		}
+		if strings.HasPrefix(reply, "-") {
+			return &ConnectError{Stage: StageAuth, Addr: addr, Err: errors.New(reply[1:]),
+				Hint: "verify the config 'username' and 'password'"}
+		}
+	}
+
+	reply, rerr := respCommand(rw, br, "PING")
+	if cerr := replyError(addr, opts.TLSConfig != nil, reply, rerr); cerr != nil {
+		return cerr
+	}
+	if strings.HasPrefix(reply, "-NOAUTH") {
+		return &ConnectError{Stage: StageAuth, Addr: addr, Err: errors.New(reply[1:]),
+			Hint: "server requires authentication, set config 'password'"}
+	}
+	return &ConnectError{Stage: StagePing, Addr: addr, Err: err,
+		Hint: "server is reachable, verify the config 'db' and 'timeout.*' settings"}
+}
+
+// replyError method returns the `ConnectError` if the reply could not be read
+// or it's not a Redis reply. Plaintext connection to the TLS only server
+// either gets closed or replied with TLS alert record.
+func replyError(addr string, tlsEnabled bool, reply string, err error) error {
+	if err == nil && len(reply) > 0 && strings.IndexByte("+-:$*", reply[0]) >= 0 {
+		return nil
+	}
+	if err == nil {
+		err = fmt.Errorf("unexpected reply %q", reply)
+	}
+	if tlsEnabled {
+		return &ConnectError{Stage: StagePing, Addr: addr, Err: err,
+			Hint: "server is not responding to Redis protocol, verify the config 'address'"}
+	}
+	return &ConnectError{Stage: StageTLS, Addr: addr, Err: err,
+		Hint: "server seems to require TLS, set config 'tls.enable = true'"}
+}
+
+// respCommand method sends the command using Redis protocol and returns the
+// first line of the reply.
+func respCommand(conn net.Conn, br *bufio.Reader, args ...string) (string, error) {
+	var b []byte
+	b = append(b, '*')
+	b = strconv.AppendInt(b, int64(len(args)), 10)
+	b = append(b, '\r', '\n')
+	for _, arg := range args {
+		b = append(b, '$')
+		b = strconv.AppendInt(b, int64(len(arg)), 10)
+		b = append(b, '\r', '\n')
+		b = append(b, arg...)
+		b = append(b, '\r', '\n')
+	}
+	if _, err := conn.Write(b); err != nil {
+		return "", err
+	}
+	line, err := br.ReadString('\n')
+	return strings.TrimRight(line, "\r\n"), err
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServer replies each command line using fn until the connection closes.
+func fakeServer(t *testing.T, fn func(cmd string) string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				br := bufio.NewReader(conn)
+				for {
+					line, err := br.ReadString('\n')
+					if err != nil {
+						return
+					}
+					// command name follows the array and bulk length lines
+					if strings.HasPrefix(line, "*") {
+						_, _ = br.ReadString('\n')
+						name, _ := br.ReadString('\n')
+						reply := fn(strings.TrimSpace(name))
+						if len(reply) == 0 {
+							return
+						}
+						_, _ = conn.Write([]byte(reply))
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func diagnoseProvider(addr, password string, tlsConfig *tls.Config) *Provider {
+	return &Provider{clientOpts: &redis.Options{
+		Network:     "tcp",
+		Addr:        addr,
+		Password:    password,
+		DialTimeout: time.Second,
+		TLSConfig:   tlsConfig,
+	}}
+}
+
+func TestRedisDiagnose(t *testing.T) {
+	pingErr := errors.New("i/o timeout")
+	stage := func(err error) string {
+		var cerr *ConnectError
+		if errors.As(err, &cerr) {
+			return cerr.Stage
+		}
+		return ""
+	}
+
+	// not diagnosed without standalone client options
+	assert.Equal(t, pingErr, (&Provider{}).diagnose(pingErr))
+
+	assert.Equal(t, StageDNS, stage(diagnoseProvider("localhost", "", nil).diagnose(pingErr)))
+	assert.Equal(t, StageDNS, stage(diagnoseProvider("not-exists.invalid:6379", "", nil).diagnose(pingErr)))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	closedAddr := ln.Addr().String()
+	_ = ln.Close()
+	assert.Equal(t, StageTCP, stage(diagnoseProvider(closedAddr, "", nil).diagnose(pingErr)))
+
+	redisAddr := fakeServer(t, func(cmd string) string {
+		if cmd == "AUTH" {
+			return "-WRONGPASS invalid username-password pair\r\n"
+		}
+		return "-NOAUTH Authentication required.\r\n"
+	})
+	err = diagnoseProvider(redisAddr, "wrong", nil).diagnose(pingErr)
+	assert.Equal(t, StageAuth, stage(err))
+	assert.True(t, strings.Contains(err.Error(), "WRONGPASS"))
+	assert.Equal(t, StageAuth, stage(diagnoseProvider(redisAddr, "", nil).diagnose(pingErr)))
+
+	// plaintext server with TLS enabled
+	err = diagnoseProvider(redisAddr, "", &tls.Config{InsecureSkipVerify: true}).diagnose(pingErr)
+	assert.Equal(t, StageTLS, stage(err))
+	assert.True(t, strings.Contains(err.Error(), "tls.enable = false"))
+
+	// TLS only server closes the plaintext connection
+	tlsAddr := fakeServer(t, func(cmd string) string { return "" })
+	err = diagnoseProvider(tlsAddr, "", nil).diagnose(pingErr)
+	assert.Equal(t, StageTLS, stage(err))
+	assert.True(t, strings.Contains(err.Error(), "tls.enable = true"))
+
+	okAddr := fakeServer(t, func(cmd string) string { return "+PONG\r\n" })
+	err = diagnoseProvider(okAddr, "", nil).diagnose(pingErr)
+	assert.Equal(t, StagePing, stage(err))
+	assert.True(t, errors.Is(err, pingErr))
+}
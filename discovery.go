@@ -0,0 +1,99 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const srvScheme = "srv://"
+
+// AddressResolver func type resolves the configured `address` into the Redis
+// endpoint addresses `host:port` in the order of preference, e.g. using the
+// Consul catalog. First address is connected.
+type AddressResolver func(address string) ([]string, error)
+
+// SetAddressResolver method sets the resolver of the Redis endpoint address,
+// it takes precedence over the DNS SRV resolution of `srv://` address. Set it
+// before the provider gets initialized.
+func (p *Provider) SetAddressResolver(fn AddressResolver) {
+	p.resolver = fn
+}
+
+// discoverable method reports whether the address is resolved using the
+// address resolver or DNS SRV records.
+func (p *Provider) discoverable(address string) bool {
+	return p.resolver != nil || strings.HasPrefix(address, srvScheme)
+}
+
+// resolveAddresses method resolves the address using the address resolver if
+// it's set, otherwise the DNS SRV records of the `srv://` address, e.g.
+// `srv://_redis._tcp.cache.internal`. Records are ordered by priority and
+// randomized by weight.
+func (p *Provider) resolveAddresses(address string) ([]string, error) {
+	var addrs []string
+	if p.resolver != nil {
+		var err error
+		if addrs, err = p.resolver(address); err != nil {
+			return nil, err
+		}
+	} else {
+		_, records, err := net.LookupSRV("", "", strings.TrimPrefix(address, srvScheme))
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(rec.Target, "."), strconv.Itoa(int(rec.Port))))
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New("no endpoint resolved for '" + address + "'")
+	}
+	return addrs, nil
+}
+
+// watchAddress method re-resolves the address every config
+// `discovery.refresh_interval`, default is `30s`, and reloads the provider if
+// the connected endpoint is no longer resolved, i.e. endpoint churn in
+// Kubernetes or Consul.
+func (p *Provider) watchAddress(address string) {
+	interval := parseDuration(p.appCfg.StringDefault(p.cfgPrefix+"discovery.refresh_interval", "30s"), "30s")
+	for {
+		select {
+		case <-p.closing:
+			return
+		case <-time.After(interval):
+		}
+		addrs, err := p.resolveAddresses(address)
+		if err != nil {
+			p.logger.Warnf("aah/cache/provider: %s unable to resolve '%s': %v", p.name, address, err)
+			continue
+		}
+
+		p.mu.Lock()
+		current, appCfg := p.clientOpts.Addr, p.appCfg
+		p.mu.Unlock()
+		if inStrings(addrs, current) {
+			continue
+		}
+		p.logger.Infof("aah/cache/provider: %s endpoint %s is no longer resolved for '%s', reconnecting", p.name, current, address)
+		if err = p.Reload(appCfg); err != nil {
+			p.logger.Error(err)
+		}
+	}
+}
+
+func inStrings(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
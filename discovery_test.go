@@ -0,0 +1,86 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"io/ioutil"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisAddressResolver(t *testing.T) {
+	var resolved int32
+	p := new(Provider)
+	p.SetAddressResolver(func(address string) ([]string, error) {
+		assert.Equal(t, "redis-service", address)
+		if atomic.AddInt32(&resolved, 1) == 1 {
+			return []string{"localhost:6379", "127.0.0.1:6379"}, nil
+		}
+		return []string{"127.0.0.1:6379"}, nil
+	})
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "redis-service"
+			discovery {
+				refresh_interval = "100ms"
+			}
+			reload {
+				drain_timeout = "10ms"
+			}
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.Equal(t, "localhost:6379", p.Client().Options().Addr)
+
+	err := mgr.CreateCache(&cache.Config{Name: "discoverycache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("discoverycache")
+	assert.Nil(t, c.Put("discovery-key1", "value1", time.Minute))
+
+	// endpoint no longer resolved, provider reconnects
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, "127.0.0.1:6379", p.Client().Options().Addr)
+	assert.Equal(t, "value1", c.Get("discovery-key1"))
+
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, p.Close())
+}
+
+func TestRedisAddressResolverError(t *testing.T) {
+	p := new(Provider)
+	p.SetAddressResolver(func(address string) ([]string, error) {
+		return nil, errors.New("catalog unavailable")
+	})
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "redis-service"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	err := mgr.InitProviders(cfg, l)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "catalog unavailable")
+
+	_, err = (&Provider{resolver: func(string) ([]string, error) { return nil, nil }}).resolveAddresses("redis-service")
+	assert.Equal(t, "no endpoint resolved for 'redis-service'", err.Error())
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/go-redis/redis"
+)
+
+// EmbeddedServer interface is the in-process Redis server of the mode
+// `embedded`. Server is registered by the package
+// `aahframe.work/cache/provider/redis/embedded`, so that it's linked only into
+// the binaries importing it, i.e. the application tests.
+type EmbeddedServer interface {
+	// Addr method returns the address of the server.
+	Addr() string
+
+	// RequireAuth method sets the password of the server.
+	RequireAuth(password string)
+
+	// Close method stops the server.
+	Close()
+}
+
+var (
+	embeddedMu        sync.RWMutex
+	newEmbeddedServer func() (EmbeddedServer, error)
+)
+
+// RegisterEmbeddedServer function registers the constructor of the in-process
+// Redis server for the mode `embedded`. It's called by the package
+// `aahframe.work/cache/provider/redis/embedded` on import.
+func RegisterEmbeddedServer(fn func() (EmbeddedServer, error)) {
+	embeddedMu.Lock()
+	defer embeddedMu.Unlock()
+	newEmbeddedServer = fn
+}
+
+// newEmbedded method starts the in-process Redis server for the mode
+// `embedded` if it's not running and points the client options to it.
+// Embedded server is meant for the application tests to run without real
+// Redis server, data is kept in memory and lost on `Close`. Few Redis features
+// are not supported by the embedded server, such as keyspace notifications and
+// `OBJECT` command.
+//
+//	import _ "aahframe.work/cache/provider/redis/embedded"
+//
+//	cache {
+//	  redis1 {
+//	    provider = "redis"
+//	    mode = "embedded"
+//	  }
+//	}
+func (p *Provider) newEmbedded(opts *redis.Options) error {
+	if p.embedded == nil {
+		embeddedMu.RLock()
+		newServer := newEmbeddedServer
+		embeddedMu.RUnlock()
+		if newServer == nil {
+			return errors.New("server is not registered, import package aahframe.work/cache/provider/redis/embedded")
+		}
+		s, err := newServer()
+		if err != nil {
+			return err
+		}
+		p.embedded = s
+	}
+	if len(opts.Password) > 0 {
+		p.embedded.RequireAuth(opts.Password)
+	}
+	opts.Network = "tcp"
+	opts.Addr = p.embedded.Addr()
+	opts.TLSConfig = nil
+	return nil
+}
+
+// Embedded method returns the in-process Redis server of the mode `embedded`,
+// it returns nil for other modes. Use `embedded.Server` to access the
+// miniredis server, e.g. to fast forward the time for expiration.
+func (p *Provider) Embedded() EmbeddedServer {
+	return p.embedded
+}
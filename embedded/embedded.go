@@ -0,0 +1,31 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package embedded registers the in-process Redis server powered by
+// miniredis for the Redis cache provider mode `embedded`, so that the
+// application tests could run without Redis server. Import it only from the
+// tests, so that miniredis is not linked into the application binary.
+//
+//	import _ "aahframe.work/cache/provider/redis/embedded"
+package embedded // import "aahframe.work/cache/provider/redis/embedded"
+
+import (
+	"aahframe.work/cache/provider/redis"
+	"github.com/alicebob/miniredis/v2"
+)
+
+func init() {
+	redis.RegisterEmbeddedServer(func() (redis.EmbeddedServer, error) {
+		return miniredis.Run()
+	})
+}
+
+// Server function returns the miniredis server of the provider in mode
+// `embedded`, tests could use it to inspect the data or to fast forward the
+// time for expiration, i.e. `embedded.Server(p).FastForward(time.Minute)`.
+// It returns nil for other modes.
+func Server(p *redis.Provider) *miniredis.Miniredis {
+	mr, _ := p.Embedded().(*miniredis.Miniredis)
+	return mr
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package embedded
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/cache/provider/redis"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedMode(t *testing.T) {
+	p := new(redis.Provider)
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			mode = "embedded"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	mr := Server(p)
+	assert.NotNil(t, mr)
+
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "embeddedcache", ProviderName: "redis1"}))
+	c := mgr.Cache("embeddedcache").(redis.Cache)
+	assert.Nil(t, c.Put("key1", "value1", time.Minute))
+	assert.Equal(t, "value1", c.Get("key1"))
+	assert.True(t, mr.Exists("embeddedcache-key1"))
+
+	mr.FastForward(2 * time.Minute)
+	assert.False(t, c.Exists("key1"))
+
+	assert.Nil(t, p.Close())
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisEmbeddedModeNotRegistered(t *testing.T) {
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", new(Provider))
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			mode = "embedded"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	err := mgr.InitProviders(cfg, l)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "embedded server is not registered")
+}
+
+func TestRedisEmbeddedModeNotEnabled(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "notembeddedcache", ProviderName: "redis1"})
+	assert.Nil(t, c.(*redisCache).p.Embedded())
+}
@@ -0,0 +1,286 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rawMarker is the first byte of the entry header. gob stream never starts
+// with zero byte, since it's prefixed with non-zero message length.
+const rawMarker = 0x00
+
+// rawTypes are the value types stored without gob, value of the type is
+// stored as is (string, []byte) or in its string form (bool, numbers).
+var rawTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Slice:   reflect.TypeOf([]byte(nil)),
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+var errInvalidRawEntry = errors.New("invalid raw entry")
+
+var readerPool = sync.Pool{New: func() interface{} { return new(bytes.Reader) }}
+
+// encodeEntry method writes the cache entry into buf with header
+// `0x00<kind><duration>:`, so that the expiration duration is readable by the
+// Lua scripts. String, []byte, bool and number values are written in raw
+// format after the header, so that the values are readable by non-Go
+// consumers, other values are gob encoded with kind `reflect.Invalid`. Entry
+// with the loader cost is gob encoded to keep the metadata used by the early
+// refresh.
+func encodeEntry(buf *bytes.Buffer, e *entry) error {
+	rv := reflect.ValueOf(e.V)
+	kind := reflect.Invalid
+	if rv.IsValid() && rawTypes[rv.Kind()] == rv.Type() && e.C == 0 {
+		kind = rv.Kind()
+	}
+
+	if e.V == NotFound {
+		writeHeader(buf, notFoundKind, e.D)
+		return nil
+	}
+	writeHeader(buf, byte(kind), e.D)
+	var num [32]byte
+	switch kind {
+	case reflect.Invalid:
+		// gob encoder is not reused, since each entry must be a self
+		// contained stream with its type information
+		return gobError(gob.NewEncoder(buf).Encode(e))
+	case reflect.String:
+		buf.WriteString(rv.String())
+	case reflect.Slice:
+		buf.Write(rv.Bytes())
+	case reflect.Bool:
+		buf.Write(strconv.AppendBool(num[:0], rv.Bool()))
+	case reflect.Float32:
+		buf.Write(strconv.AppendFloat(num[:0], rv.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		buf.Write(strconv.AppendFloat(num[:0], rv.Float(), 'g', -1, 64))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.Write(strconv.AppendInt(num[:0], rv.Int(), 10))
+	default:
+		buf.Write(strconv.AppendUint(num[:0], rv.Uint(), 10))
+	}
+	return nil
+}
+
+// writeHeader method writes the entry header `0x00<kind><duration>:` into buf.
+func writeHeader(buf *bytes.Buffer, kind byte, d time.Duration) {
+	buf.WriteByte(rawMarker)
+	buf.WriteByte(kind)
+	var num [20]byte
+	buf.Write(strconv.AppendInt(num[:0], int64(d), 10))
+	buf.WriteByte(':')
+}
+
+// envelope method returns the kind and payload of the entry if it's wrapped by
+// encryption or compression.
+func envelope(b []byte) (byte, []byte, bool) {
+	if len(b) < 2 || b[0] != rawMarker || (b[1] != encryptedKind && b[1] != compressedKind) {
+		return 0, nil, false
+	}
+	idx := bytes.IndexByte(b, ':')
+	if idx < 2 {
+		return 0, nil, false
+	}
+	return b[1], b[idx+1:], true
+}
+
+// encode method writes the cache entry into buf. Encoded entry exceeding the
+// `max_value_size` is handled per oversize policy, then it's encrypted if the
+// encryption is enabled. Entry is prefixed with the format version if it's
+// configured.
+func (r *redisCache) encode(k string, buf *bytes.Buffer, e *entry) error {
+	if r.formatVersion > 0 {
+		enc := acquireBuffer()
+		defer releaseBuffer(enc)
+		if err := r.encodePayload(k, enc, e); err != nil {
+			return err
+		}
+		r.writeVersion(buf, e.D, enc.Bytes())
+		return nil
+	}
+	return r.encodePayload(k, buf, e)
+}
+
+func (r *redisCache) encodePayload(k string, buf *bytes.Buffer, e *entry) error {
+	if r.aead == nil && r.maxValueSize <= 0 {
+		return r.encodeValue(buf, e)
+	}
+
+	plain := acquireBuffer()
+	defer releaseBuffer(plain)
+	if err := r.encodeValue(plain, e); err != nil {
+		return err
+	}
+	if r.maxValueSize > 0 && plain.Len() > r.maxValueSize {
+		if err := r.oversize(k, plain, e.D); err != nil {
+			return err
+		}
+	}
+	if r.aead == nil {
+		_, err := buf.Write(plain.Bytes())
+		return err
+	}
+	return r.seal(k, buf, e.D, plain.Bytes())
+}
+
+// decode method reads the cache entry from b written by `encode`. Entry
+// without encryption is decoded as is, so that the encryption could be
+// enabled for the existing cache. Entry of the older format version is
+// migrated first. Entry without header is plain JSON in JSON layout.
+func (r *redisCache) decode(k string, b []byte, e *entry) error {
+	if _, wrapped, found := revision(b); found {
+		b = wrapped
+	}
+	if r.layout == layoutJSON && (len(b) == 0 || b[0] != rawMarker) {
+		return decodeJSON(b, e)
+	}
+	b, err := r.migrate(k, b)
+	if err != nil {
+		return err
+	}
+	for {
+		kind, payload, found := envelope(b)
+		if !found {
+			return r.decodeValue(b, e)
+		}
+		if kind == encryptedKind {
+			b, err = r.open(k, payload)
+		} else {
+			b, err = decompress(payload)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// decodeEntry method reads the cache entry from b written by `encodeEntry`.
+// Entry without header is gob encoded by the earlier versions.
+func decodeEntry(b []byte, e *entry) error {
+	if len(b) == 0 || b[0] != rawMarker {
+		return gobDecode(b, e)
+	}
+
+	if len(b) < 2 {
+		return errInvalidRawEntry
+	}
+	if b[1] == notFoundKind {
+		if len(b) < 4 || b[len(b)-1] != ':' {
+			return errInvalidRawEntry
+		}
+		d, ok := parseHeaderDuration(b[2 : len(b)-1])
+		if !ok {
+			return errInvalidRawEntry
+		}
+		e.D, e.V = d, NotFound
+		return nil
+	}
+	kind := reflect.Kind(b[1])
+	t, found := rawTypes[kind]
+	idx := bytes.IndexByte(b, ':')
+	if (!found && kind != reflect.Invalid) || idx < 2 {
+		return errInvalidRawEntry
+	}
+	if kind == reflect.Invalid {
+		return gobDecode(b[idx+1:], e)
+	}
+	d, ok := parseHeaderDuration(b[2:idx])
+	if !ok {
+		return errInvalidRawEntry
+	}
+	e.D = d
+
+	switch kind {
+	case reflect.String:
+		e.V = string(b[idx+1:])
+		return nil
+	case reflect.Slice:
+		e.V = append([]byte(nil), b[idx+1:]...)
+		return nil
+	}
+
+	var err error
+	s := string(b[idx+1:])
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		var bv bool
+		bv, err = strconv.ParseBool(s)
+		v.SetBool(bv)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, t.Bits())
+		v.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, t.Bits())
+		v.SetInt(n)
+	default:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, t.Bits())
+		v.SetUint(n)
+	}
+	if err != nil {
+		return errInvalidRawEntry
+	}
+	e.V = v.Interface()
+	return nil
+}
+
+// gobDecode method decodes the gob encoded entry from b. Readers are pooled,
+// gob decoder is not reused, since each entry is a self contained stream.
+func gobDecode(b []byte, e *entry) error {
+	rd := readerPool.Get().(*bytes.Reader)
+	rd.Reset(b)
+	err := gob.NewDecoder(rd).Decode(e)
+	rd.Reset(nil)
+	readerPool.Put(rd)
+	return gobError(err)
+}
+
+// parseHeaderDuration method parses the decimal duration of the entry header
+// without allocation.
+func parseHeaderDuration(b []byte) (time.Duration, bool) {
+	neg := len(b) > 0 && b[0] == '-'
+	if neg {
+		b = b[1:]
+	}
+	if len(b) == 0 {
+		return 0, false
+	}
+	var d int64
+	for _, c := range b {
+		if c < '0' || c > '9' || d > (math.MaxInt64-int64(c-'0'))/10 {
+			return 0, false
+		}
+		d = d*10 + int64(c-'0')
+	}
+	if neg {
+		d = -d
+	}
+	return time.Duration(d), true
+}
@@ -0,0 +1,154 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeEntry(t *testing.T) {
+	type sample struct {
+		Name string
+	}
+	values := []interface{}{
+		"value1", []byte("value2"), true, int(-1), int8(-8), int16(-16), int32(-32),
+		int64(-64), uint(1), uint8(8), uint16(16), uint32(32), uint64(64),
+		float32(3.2), float64(6.4), sample{Name: "gob"}, "",
+	}
+	for _, v := range values {
+		buf := new(bytes.Buffer)
+		assert.Nil(t, encodeEntry(buf, &entry{D: time.Minute, V: v}))
+		var e entry
+		assert.Nil(t, decodeEntry(buf.Bytes(), &e))
+		assert.Equal(t, v, e.V)
+		assert.Equal(t, time.Minute, e.D)
+	}
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, encodeEntry(buf, &entry{D: time.Minute, V: "value1"}))
+	assert.Equal(t, "\x00\x1860000000000:value1", buf.String())
+
+	// entry with loader cost is gob encoded
+	buf.Reset()
+	assert.Nil(t, encodeEntry(buf, &entry{D: time.Minute, V: "value1", C: time.Second}))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("\x00\x0060000000000:")))
+	var e entry
+	assert.Nil(t, decodeEntry(buf.Bytes(), &e))
+	assert.Equal(t, entry{D: time.Minute, V: "value1", C: time.Second}, e)
+
+	// entry without header by earlier versions
+	buf.Reset()
+	assert.Nil(t, gob.NewEncoder(buf).Encode(&entry{D: time.Minute, V: "value1"}))
+	e = entry{}
+	assert.Nil(t, decodeEntry(buf.Bytes(), &e))
+	assert.Equal(t, "value1", e.V)
+
+	for _, b := range []string{"\x00", "\x00\x18", "\x00\xff0:v", "\x00\x18x:v", "\x00\x01:v", "\x00\x020:yes"} {
+		assert.Equal(t, errInvalidRawEntry, decodeEntry([]byte(b), &e))
+	}
+}
+
+func TestRedisRawValues(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "rawcache", ProviderName: "redis1"})
+
+	assert.Nil(t, c.Put("str1", "value1", 10*time.Second))
+	assert.Nil(t, c.Put("bytes1", []byte("value2"), 10*time.Second))
+	assert.Nil(t, c.Put("int1", 42, 10*time.Second))
+	assert.Equal(t, "value1", c.Get("str1"))
+	assert.Equal(t, []byte("value2"), c.Get("bytes1"))
+	assert.Equal(t, 42, c.Get("int1"))
+
+	// value is readable by non-Go consumers
+	v, err := c.(*redisCache).client().Get("rawcache-str1").Bytes()
+	assert.Nil(t, err)
+	assert.True(t, bytes.HasSuffix(v, []byte(":value1")))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestParseHeaderDuration(t *testing.T) {
+	for s, want := range map[string]time.Duration{
+		"0":                   0,
+		"60000000000":         time.Minute,
+		"-1":                  -1,
+		"9223372036854775807": time.Duration(math.MaxInt64),
+	} {
+		d, ok := parseHeaderDuration([]byte(s))
+		assert.True(t, ok, s)
+		assert.Equal(t, want, d)
+	}
+	for _, s := range []string{"", "-", "1m", "9223372036854775808"} {
+		_, ok := parseHeaderDuration([]byte(s))
+		assert.False(t, ok, s)
+	}
+}
+
+type benchEntry struct {
+	ID    int
+	Name  string
+	Roles []string
+}
+
+func BenchmarkEncodeEntryString(b *testing.B) {
+	benchmarkEncodeEntry(b, "value1")
+}
+
+func BenchmarkEncodeEntryStruct(b *testing.B) {
+	benchmarkEncodeEntry(b, benchEntry{ID: 1, Name: "aah", Roles: []string{"admin"}})
+}
+
+func BenchmarkDecodeEntryString(b *testing.B) {
+	benchmarkDecodeEntry(b, "value1")
+}
+
+func BenchmarkDecodeEntryStruct(b *testing.B) {
+	benchmarkDecodeEntry(b, benchEntry{ID: 1, Name: "aah", Roles: []string{"admin"}})
+}
+
+func benchmarkEncodeEntry(b *testing.B, v interface{}) {
+	registerType(reflect.TypeOf(v))
+	e := &entry{D: time.Minute, V: v}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf := acquireBuffer()
+		if err := encodeEntry(buf, e); err != nil {
+			b.Fatal(err)
+		}
+		releaseBuffer(buf)
+	}
+}
+
+func benchmarkDecodeEntry(b *testing.B, v interface{}) {
+	registerType(reflect.TypeOf(v))
+	buf := new(bytes.Buffer)
+	if err := encodeEntry(buf, &entry{D: time.Minute, V: v}); err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var e entry
+		if err := decodeEntry(data, &e); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+// encryptedKind is the entry header kind of the encrypted entry.
+const encryptedKind = 0xfe
+
+var errEncryptionNotEnabled = errors.New("entry is encrypted, encryption is not enabled")
+
+// KeyProvider func type returns the AES encryption key for given cache name.
+// Key length must be 16, 24 or 32 bytes to select AES-128, AES-192 or
+// AES-256.
+type KeyProvider func(cacheName string) ([]byte, error)
+
+// SetKeyProvider method sets the encryption key provider, it's used for the
+// caches with `encryption.enable = true` instead of config
+// `encryption.key`. Set it before the caches are created.
+func (p *Provider) SetKeyProvider(fn KeyProvider) {
+	p.keyProvider = fn
+}
+
+// newAEAD method creates the AES-GCM cipher for given cache. Key is obtained
+// from the key provider if it's set otherwise from config `encryption.key`
+// (base64 encoded).
+func (p *Provider) newAEAD(cacheName string) (cipher.AEAD, error) {
+	var key []byte
+	var err error
+	if p.keyProvider != nil {
+		key, err = p.keyProvider(cacheName)
+	} else {
+		key, err = base64.StdEncoding.DecodeString(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "encryption.key"), ""))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return nil, errors.New("key is not configured")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal method writes the encoded entry sealed with AES-GCM using the key name as
+// additional data into buf, after the entry header with kind `encryptedKind`,
+// so that the expiration duration is still readable by the Lua scripts.
+func (r *redisCache) seal(k string, buf *bytes.Buffer, d time.Duration, plain []byte) error {
+	nonce := make([]byte, r.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	writeHeader(buf, encryptedKind, d)
+	buf.Write(nonce)
+	buf.Write(r.aead.Seal(nil, nonce, plain, []byte(k)))
+	return nil
+}
+
+// open method returns the encoded entry from the sealed payload.
+func (r *redisCache) open(k string, sealed []byte) ([]byte, error) {
+	if r.aead == nil {
+		return nil, errEncryptionNotEnabled
+	}
+	if len(sealed) < r.aead.NonceSize() {
+		return nil, errInvalidRawEntry
+	}
+	return r.aead.Open(nil, sealed[:r.aead.NonceSize()], sealed[r.aead.NonceSize():], []byte(k))
+}
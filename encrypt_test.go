@@ -0,0 +1,109 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisEncryption(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				securecache {
+					encryption {
+						enable = true
+						key = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+					}
+				}
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "securecache", ProviderName: "redis1"}))
+	c := mgr.Cache("securecache").(Cache)
+
+	assert.Nil(t, c.Put("ssn", "123-45-6789", 10*time.Second))
+	assert.Equal(t, "123-45-6789", c.Get("ssn"))
+
+	// value is not readable in Redis
+	rc := c.(*redisCache)
+	b, err := rc.client().Get("securecache-ssn").Bytes()
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(b, []byte("123-45-6789")))
+	assert.Equal(t, byte(encryptedKind), b[1])
+
+	// slide and touch read the expiration from header
+	assert.Nil(t, c.Touch("ssn"))
+
+	// value is bound to its key
+	assert.Nil(t, rc.client().Set("securecache-other", b, 10*time.Second).Err())
+	_, err = c.GetE("other")
+	assert.NotNil(t, err)
+
+	// existing unencrypted entry is readable
+	assert.Nil(t, rc.client().Set("securecache-plain", "\x00\x180:value", 10*time.Second).Err())
+	assert.Equal(t, "value", c.Get("plain"))
+
+	// cache without encryption
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "plaincache", ProviderName: "redis1"}))
+	pc := mgr.Cache("plaincache").(*redisCache)
+	var e entry
+	assert.Equal(t, errEncryptionNotEnabled, pc.decode("ssn", b, &e))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisEncryptionKeyProvider(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			encryption.enable = true
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	p.SetKeyProvider(func(cacheName string) ([]byte, error) {
+		if cacheName == "failcache" {
+			return nil, errors.New("vault unavailable")
+		}
+		return []byte("0123456789abcdef"), nil
+	})
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "securecache", ProviderName: "redis1"}))
+	c := mgr.Cache("securecache")
+	assert.Nil(t, c.Put("key1", map[string]int{"a": 1}, 10*time.Second))
+	assert.Equal(t, map[string]int{"a": 1}, c.Get("key1"))
+	assert.Nil(t, c.Flush())
+
+	err := mgr.CreateCache(&cache.Config{Name: "failcache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/failcache: encryption vault unavailable", err.Error())
+}
+
+func TestRedisEncryptionNoKey(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			encryption.enable = true
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "securecache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/securecache: encryption key is not configured", err.Error())
+}
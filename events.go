@@ -0,0 +1,216 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// Event struct is the cache change event read from the cache event log. Op is
+// `put`, `delete`, `ttl`, `flush`, `delete_pattern`, `import` or `warmup`.
+// Flush event is written for Flush, InvalidateAll and scoped Flush, its key is
+// the scope if any, otherwise empty. Key of `delete_pattern` is the pattern,
+// `import` and `warmup` events have empty key.
+type Event struct {
+	ID       string
+	Op       string
+	Key      string
+	Instance string
+	Time     time.Time
+}
+
+// eventLog struct writes the cache change events to the Redis Stream of the
+// cache, so that the downstream systems (search indexes, CDNs) could react to
+// the cache changes with at-least-once delivery and replay, unlike Pub/Sub
+// which loses the events while the consumer is down.
+//
+// It's enabled per cache via config `event_log.enable = true`. Stream name is
+// configured via `event_log.stream`, default is
+// `aah:cache:events:<cache key prefix>`, it's trimmed to approximately
+// `event_log.max_len` (default 100000) events. Events are written after the
+// change is stored in Redis.
+type eventLog struct {
+	stream string
+	maxLen int64
+}
+
+// Event operations of the bulk changes other than Flush.
+const (
+	opWarmup        = "warmup"
+	opImport        = "import"
+	opDeletePattern = "delete_pattern"
+)
+
+func (p *Provider) newEventLog(r *redisCache) *eventLog {
+	return &eventLog{
+		stream: p.appCfg.StringDefault(p.cacheCfgKey(r.Name(), "event_log.stream"), "aah:cache:events:"+r.keyPrefix),
+		maxLen: int64(p.appCfg.IntDefault(p.cacheCfgKey(r.Name(), "event_log.max_len"), 100000)),
+	}
+}
+
+// emit method writes the cache change event to the event log and the audit
+// trail, if it's enabled.
+func (r *redisCache) emit(op, k string) {
+	r.emitBy(op, k, "")
+}
+
+// emitBy method is same as `emit`, the change is attributed to the actor in
+// the audit trail.
+func (r *redisCache) emitBy(op, k, actor string) {
+	r.audit(op, k, actor)
+	if r.events == nil {
+		return
+	}
+	err := r.client().XAdd(&redis.XAddArgs{
+		Stream:       r.events.stream,
+		MaxLenApprox: r.events.maxLen,
+		Values:       map[string]interface{}{"op": op, "key": k, "instance": r.p.id},
+	}).Err()
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.logFor(op, k).errorf("aah/cache/%s: key(%s) event log %v", r.Name(), k, err)
+	}
+}
+
+// EventConsumer struct reads the cache change events from the cache event log
+// as the member of the consumer group. Events are delivered at-least-once,
+// events read but not acknowledged via `Ack` are delivered again to the
+// consumer on its next start. Create it using `Cache.EventConsumer`.
+type EventConsumer struct {
+	r        *redisCache
+	group    string
+	consumer string
+	pending  string
+}
+
+// EventConsumer method returns the consumer of the cache event log for given
+// consumer group and consumer name. Consumer group is created if it does not
+// exists, it starts from the oldest event retained in the event log.
+//
+//	ec, err := c.EventConsumer("search-indexer", hostname)
+//	for {
+//		events, err := ec.Read(100, 5*time.Second)
+//		// reindex the keys
+//		err = ec.Ack(ids...)
+//	}
+func (r *redisCache) EventConsumer(group, consumer string) (*EventConsumer, error) {
+	if r.events == nil {
+		return nil, fmt.Errorf("aah/cache/%s: event_log is not enabled", r.Name())
+	}
+	// MKSTREAM creates the stream if nothing is written yet
+	cmd := redis.NewStatusCmd("xgroup", "create", r.events.stream, group, "0", "mkstream")
+	_ = r.client().Process(cmd)
+	if err := cmd.Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		r.p.done(err)
+		return nil, fmt.Errorf("aah/cache/%s: event consumer(%s) %v", r.Name(), group, err)
+	}
+	return &EventConsumer{r: r, group: group, consumer: consumer, pending: "0"}, nil
+}
+
+// Read method returns up to count events of the consumer, it waits up to block
+// for the new events, zero block returns immediately. Events delivered to the
+// consumer earlier but not acknowledged are returned first.
+func (ec *EventConsumer) Read(count int64, block time.Duration) ([]Event, error) {
+	if len(ec.pending) > 0 {
+		// pending events after the last one returned
+		events, err := ec.read(ec.pending, count, -1)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			ec.pending = events[len(events)-1].ID
+			return events, nil
+		}
+		ec.pending = ""
+	}
+	if block <= 0 {
+		block = -1
+	}
+	return ec.read(">", count, block)
+}
+
+func (ec *EventConsumer) read(id string, count int64, block time.Duration) ([]Event, error) {
+	r := ec.r
+	c := r.client()
+	if block >= 0 {
+		c = r.blockingClient()
+	}
+	streams, err := c.XReadGroup(&redis.XReadGroupArgs{
+		Group:    ec.group,
+		Consumer: ec.consumer,
+		Streams:  []string{r.events.stream, id},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	r.p.done(notacacheMiss(err))
+	if err != nil {
+		if notacacheMiss(err) == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("aah/cache/%s: event consumer(%s) %v", r.Name(), ec.group, err)
+	}
+	var events []Event
+	for _, s := range streams {
+		for _, m := range s.Messages {
+			events = append(events, newEvent(m))
+		}
+	}
+	return events, nil
+}
+
+// Ack method acknowledges the events processed by the consumer, so they're
+// not delivered again.
+func (ec *EventConsumer) Ack(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	r := ec.r
+	err := r.client().XAck(r.events.stream, ec.group, ids...).Err()
+	r.p.done(err)
+	if err != nil {
+		return fmt.Errorf("aah/cache/%s: event consumer(%s) %v", r.Name(), ec.group, err)
+	}
+	return nil
+}
+
+// ReplayEvents method returns up to count events of the cache event log
+// starting from the event ID inclusive, `-` replays from the oldest retained
+// event. It's independent of the consumer groups.
+func (r *redisCache) ReplayEvents(fromID string, count int64) ([]Event, error) {
+	if r.events == nil {
+		return nil, fmt.Errorf("aah/cache/%s: event_log is not enabled", r.Name())
+	}
+	msgs, err := r.client().XRangeN(r.events.stream, fromID, "+", count).Result()
+	r.p.done(err)
+	if err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: event replay %v", r.Name(), err)
+	}
+	events := make([]Event, 0, len(msgs))
+	for _, m := range msgs {
+		events = append(events, newEvent(m))
+	}
+	return events, nil
+}
+
+// newEvent method returns the event of the stream message, event time is the
+// milliseconds part of the message ID.
+func newEvent(m redis.XMessage) Event {
+	e := Event{ID: m.ID}
+	e.Op, _ = m.Values["op"].(string)
+	e.Key, _ = m.Values["key"].(string)
+	e.Instance, _ = m.Values["instance"].(string)
+	if idx := strings.IndexByte(m.ID, '-'); idx > 0 {
+		if ms, err := strconv.ParseInt(m.ID[:idx], 10, 64); err == nil {
+			e.Time = time.Unix(0, ms*int64(time.Millisecond))
+		}
+	}
+	return e
+}
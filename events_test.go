@@ -0,0 +1,126 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisEventLog(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				eventcache {
+					event_log {
+						enable = true
+						stream = "test:events:eventcache"
+						max_len = 1000
+					}
+				}
+			}
+		}
+	}
+`, &cache.Config{Name: "eventcache", ProviderName: "redis1"}).(Cache)
+	r := c.(*redisCache)
+	assert.Nil(t, r.client().Del("test:events:eventcache").Err())
+
+	ec, err := c.EventConsumer("indexer", "consumer1")
+	assert.Nil(t, err)
+	events, err := ec.Read(10, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	assert.Nil(t, c.Put("key1", "value1", time.Minute))
+	assert.Nil(t, c.Delete("key1"))
+	assert.Nil(t, c.Flush())
+
+	events, err = ec.Read(10, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(events))
+	var ops []string
+	for _, e := range events {
+		ops = append(ops, e.Op)
+		assert.Equal(t, r.p.id, e.Instance)
+		assert.False(t, e.Time.IsZero())
+	}
+	assert.Equal(t, []string{"put", "delete", "flush"}, ops)
+	assert.Equal(t, "key1", events[0].Key)
+
+	// unacknowledged events are delivered again on restart
+	ec, err = c.EventConsumer("indexer", "consumer1")
+	assert.Nil(t, err)
+	redelivered, err := ec.Read(10, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(redelivered))
+	assert.Nil(t, ec.Ack(events[0].ID, events[1].ID, events[2].ID))
+	ec, err = c.EventConsumer("indexer", "consumer1")
+	assert.Nil(t, err)
+	events, err = ec.Read(10, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	replayed, err := c.ReplayEvents("-", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(replayed))
+
+	assert.Nil(t, r.client().Del("test:events:eventcache").Err())
+}
+
+func TestRedisEventLogBulkOps(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			namespace = "evapp:"
+			caches {
+				eventbulkcache {
+					event_log {
+						enable = true
+					}
+					invalidation {
+						enable = true
+					}
+				}
+			}
+		}
+	}
+`, &cache.Config{Name: "eventbulkcache", ProviderName: "redis1"}).(Cache)
+	r := c.(*redisCache)
+
+	// defaults are derived from the cache key prefix
+	assert.Equal(t, "aah:cache:events:evapp:eventbulkcache-", r.events.stream)
+	assert.Equal(t, "aah:cache:invalidate:evapp:eventbulkcache-", r.inv.channel)
+	assert.Nil(t, c.Put("user:2", "value2", time.Minute))
+	var buf bytes.Buffer
+	assert.Nil(t, c.Export(&buf))
+	assert.Nil(t, r.client().Del(r.events.stream).Err())
+
+	assert.Nil(t, c.Warmup(context.Background(), map[string]WarmEntry{"user:1": {Value: "value1", TTL: time.Minute}}))
+	_, err := c.DeleteByPattern("user:*")
+	assert.Nil(t, err)
+	assert.Nil(t, c.Import(&buf))
+
+	events, err := c.ReplayEvents("-", 10)
+	assert.Nil(t, err)
+	var ops []string
+	for _, e := range events {
+		ops = append(ops, e.Op)
+	}
+	assert.Equal(t, []string{"warmup", "delete_pattern", "import"}, ops)
+	assert.Equal(t, "user:*", events[1].Key)
+
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, r.client().Del(r.events.stream).Err())
+}
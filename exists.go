@@ -0,0 +1,64 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-redis/redis"
+)
+
+// ExistsMulti method reports whether the cache entry exists for each of given
+// keys in single Redis round trip. Multi-key EXISTS replies only the count of
+// existing keys, so EXISTS per key is pipelined instead, which works in Redis
+// Cluster mode too.
+//
+//	found, err := c.ExistsMulti("token-1", "token-2", "token-3")
+//	if !found["token-2"] {
+//		// revoked
+//	}
+func (r *redisCache) ExistsMulti(keys ...string) (map[string]bool, error) {
+	k := strings.Join(keys, ",")
+	start := r.begin(opExists, k)
+	found := make(map[string]bool, len(keys))
+	if r.circuitOpen() {
+		if r.fallback == nil {
+			r.stats.error()
+			r.observeError(opExists, k, ErrCircuitOpen, start)
+			return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+		}
+		for _, key := range keys {
+			_, found[key] = r.fallback.Get(key)
+		}
+		r.observe(opExists, k, resultOK, start)
+		return found, nil
+	}
+	if len(keys) == 0 {
+		return found, nil
+	}
+
+	cmds := make([]*redis.IntCmd, len(keys))
+	err := r.read(func(c Commander) error {
+		_, err := c.Pipelined(func(pipe redis.Pipeliner) error {
+			for i, key := range keys {
+				cmds[i] = pipe.Exists(r.key(key))
+			}
+			return nil
+		})
+		return err
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opExists, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	for i, key := range keys {
+		found[key] = cmds[i].Val() == 1
+	}
+	r.observe(opExists, k, resultOK, start)
+	return found, nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisExistsMulti(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "existscache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("token-1", "u1", time.Minute))
+	assert.Nil(t, c.Put("token-3", "u3", time.Minute))
+
+	found, err := c.ExistsMulti("token-1", "token-2", "token-3")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"token-1": true, "token-2": false, "token-3": true}, found)
+
+	found, err = c.ExistsMulti()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(found))
+
+	assert.Nil(t, c.Flush())
+}
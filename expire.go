@@ -0,0 +1,124 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// touchScript resets the entry expiration to the duration (nanoseconds) read
+// from the entry header. It returns 0 if the entry does not exists and -1 if
+// the entry has no header.
+var touchScript = redis.NewScript(`local v = redis.call("get", KEYS[1])
+if not v then
+	return 0
+end
+if string.byte(v, 1) ~= 0 then
+	return -1
+end
+local d = tonumber(string.match(v, "^..(%d+):"))
+if not d then
+	return -1
+end
+local ms = math.floor(d / 1000000)
+if ms > 0 then
+	redis.call("pexpire", KEYS[1], ms)
+end
+return 1`)
+
+// errTouchJSONLayout returned by Touch for the entry stored in JSON layout.
+var errTouchJSONLayout = errors.New("touch is not supported for the entry of json layout, use Expire")
+
+// Touch method resets the cache entry expiration to its original duration
+// without transferring the value. It returns `ErrCacheMiss` if the entry does
+// not exists. It's not supported for the entries stored in JSON layout, since
+// the duration lives only in the Redis key TTL, use `Expire` instead.
+func (r *redisCache) Touch(k string) error {
+	start := r.begin(opTTL, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opTTL, k, ErrCircuitOpen, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	n, err := touchScript.Run(r.client(), []string{r.key(k)}).Int64()
+	if err == nil && n == -1 {
+		if r.layout == layoutJSON {
+			err = errTouchJSONLayout
+		} else {
+			n, err = r.touchLegacy(k)
+		}
+	}
+	r.p.done(notacacheMiss(err))
+	if err = notacacheMiss(err); err != nil {
+		r.stats.error()
+		r.observeError(opTTL, k, err, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opTTL, k, resultOK, start)
+	if n == 0 {
+		return ErrCacheMiss
+	}
+	return nil
+}
+
+// touchLegacy method resets the expiration of the entry stored by earlier
+// versions, its duration is gob encoded. It returns 0 if the entry does not
+// exists.
+func (r *redisCache) touchLegacy(k string) (int64, error) {
+	b, err := r.client().Get(r.key(k)).Bytes()
+	if err != nil {
+		return 0, err
+	}
+	var e entry
+	if err = r.decode(k, b, &e); err != nil || e.D <= 0 {
+		return 1, err
+	}
+	found, err := r.client().PExpire(r.key(k), e.D).Result()
+	if !found {
+		return 0, err
+	}
+	return 1, err
+}
+
+// Expire method sets the new expiration of the cache entry without
+// transferring the value, zero or negative duration removes the expiration.
+// Expiration is subject to the `ttl.*` policy same as Put, e.g. it's clamped
+// to `ttl.max` instead of removed. It returns `ErrCacheMiss` if the entry does
+// not exists.
+func (r *redisCache) Expire(k string, d time.Duration) error {
+	start := r.begin(opTTL, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opTTL, k, ErrCircuitOpen, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	d = r.expiration(k, d)
+	var found bool
+	var err error
+	if d > 0 {
+		found, err = r.client().PExpire(r.key(k), d).Result()
+	} else if found, err = r.client().Persist(r.key(k)).Result(); err == nil && !found {
+		// PERSIST replies 0 for the entry without expiration too
+		var n int64
+		n, err = r.client().Exists(r.key(k)).Result()
+		found = n == 1
+	}
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opTTL, k, err, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opTTL, k, resultOK, start)
+	if !found {
+		return ErrCacheMiss
+	}
+	r.emit(opTTL, k)
+	return nil
+}
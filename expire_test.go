@@ -0,0 +1,107 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisTouch(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "touchcache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("session1", map[string]string{"user": "aah"}, 4*time.Second))
+	time.Sleep(1100 * time.Millisecond)
+	assert.Nil(t, c.Touch("session1"))
+	d, err := c.TTL("session1")
+	assert.Nil(t, err)
+	assert.True(t, d > 3*time.Second)
+
+	assert.Equal(t, ErrCacheMiss, c.Touch("notexists"))
+
+	// entry stored by earlier versions
+	buf := new(bytes.Buffer)
+	assert.Nil(t, gob.NewEncoder(buf).Encode(&entry{D: 4 * time.Second, V: "value1"}))
+	assert.Nil(t, c.(*redisCache).client().Set("touchcache-key1", buf.Bytes(), 2*time.Second).Err())
+	assert.Nil(t, c.Touch("key1"))
+	d, err = c.TTL("key1")
+	assert.Nil(t, err)
+	assert.True(t, d > 3*time.Second)
+
+	// sub-second duration of the entry stored by earlier versions
+	buf.Reset()
+	assert.Nil(t, gob.NewEncoder(buf).Encode(&entry{D: 500 * time.Millisecond, V: "value2"}))
+	assert.Nil(t, c.(*redisCache).client().Set("touchcache-key2", buf.Bytes(), 100*time.Millisecond).Err())
+	assert.Nil(t, c.Touch("key2"))
+	d, err = c.TTL("key2")
+	assert.Nil(t, err)
+	assert.True(t, d > 100*time.Millisecond)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisTouchJSONLayout(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			format {
+				layout = "json"
+			}
+		}
+	}
+`, &cache.Config{Name: "touchjsoncache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("key1", "value1", 4*time.Second))
+	err := c.Touch("key1")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), errTouchJSONLayout.Error())
+	assert.Equal(t, ErrCacheMiss, c.Touch("notexists"))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisExpire(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "expirecache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("key1", "value1", 4*time.Second))
+	assert.Nil(t, c.Expire("key1", 20*time.Second))
+	d, err := c.TTL("key1")
+	assert.Nil(t, err)
+	assert.True(t, d > 19*time.Second)
+
+	assert.Nil(t, c.Expire("key1", 0))
+	d, err = c.TTL("key1")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(-1), d)
+
+	// no expiration already
+	assert.Nil(t, c.Expire("key1", 0))
+
+	assert.Equal(t, ErrCacheMiss, c.Expire("notexists", time.Second))
+	assert.Equal(t, ErrCacheMiss, c.Expire("notexists", 0))
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// exportRecord struct is the cache entry in export format. Key is without
+// the cache key prefix, TTL is the remaining time to live in milliseconds
+// where zero means no expiration and Value is the encoded entry.
+type exportRecord struct {
+	Key   string `json:"key"`
+	TTL   int64  `json:"ttl"`
+	Value []byte `json:"value"`
+}
+
+// Export method streams all the cache entries into w as JSON lines of
+// `{"key": "<key>", "ttl": <milliseconds>, "value": "<base64 encoded entry>"}`.
+// Keys are exported without the cache key prefix, so that the entries could
+// be imported into cache with other namespace or Redis instance via `Import`.
+// Keys of other types within the cache key prefix, e.g. the hash of
+// `PutFields` or the list of the queue, are skipped and logged at warn level.
+func (r *redisCache) Export(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	var mu sync.Mutex // shards are scanned concurrently
+	prefix := r.entryPrefix()
+	err := r.scanKeys(escapeGlob(prefix)+"*", func(c Commander, keys []string) error {
+		gets := make([]*redis.StringCmd, len(keys))
+		ttls := make([]*redis.DurationCmd, len(keys))
+		// errors are checked per command, so the key of other type doesn't
+		// fail the export
+		_, _ = c.Pipelined(func(pipe redis.Pipeliner) error {
+			for i, k := range keys {
+				gets[i] = pipe.Get(k)
+				ttls[i] = pipe.PTTL(k)
+			}
+			return nil
+		})
+		mu.Lock()
+		defer mu.Unlock()
+		for i, k := range keys {
+			v, err := gets[i].Bytes()
+			switch {
+			case err == redis.Nil:
+				// entry is expired or deleted since scan
+				continue
+			case err != nil && strings.HasPrefix(err.Error(), "WRONGTYPE"):
+				r.logger.warnf("aah/cache/%s: export skipped key(%s), it's not a cache entry", r.Name(), strings.TrimPrefix(k, prefix))
+				continue
+			case err != nil:
+				return err
+			}
+			rec := exportRecord{Key: strings.TrimPrefix(k, prefix), Value: v}
+			if d := ttlValue(ttls[i].Val()); d > 0 {
+				rec.TTL = durationMillis(d)
+			}
+			if err = enc.Encode(&rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		return fmt.Errorf("aah/cache/%s: export %v", r.Name(), err)
+	}
+	return nil
+}
+
+// Import method reads the cache entries exported by `Export` from rd and
+// stores them into cache store using pipelined writes, existing entries are
+// overwritten. Remaining time to live is subject to the `ttl.*` policy same as
+// Put.
+func (r *redisCache) Import(rd io.Reader) error {
+	dec := json.NewDecoder(rd)
+	batch := make([]exportRecord, 0, warmupBatchSize)
+	write := func() error {
+		prefix := r.entryPrefix()
+		_, err := r.client().Pipelined(func(pipe redis.Pipeliner) error {
+			for _, rec := range batch {
+				pipe.Set(prefix+rec.Key, rec.Value, r.expiration(rec.Key, time.Duration(rec.TTL)*time.Millisecond))
+			}
+			return nil
+		})
+		r.p.done(err)
+		if err != nil {
+			r.stats.error()
+			return err
+		}
+		for range batch {
+			r.stats.put()
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		var rec exportRecord
+		if err := dec.Decode(&rec); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("aah/cache/%s: import %v", r.Name(), err)
+		}
+		if batch = append(batch, rec); len(batch) == warmupBatchSize {
+			if err := write(); err != nil {
+				return fmt.Errorf("aah/cache/%s: import %v", r.Name(), err)
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := write(); err != nil {
+			return fmt.Errorf("aah/cache/%s: import %v", r.Name(), err)
+		}
+	}
+	if r.local != nil {
+		r.local.Flush()
+	}
+	if r.inv != nil {
+		r.inv.publish("")
+	}
+	r.emit(opImport, "")
+	return nil
+}
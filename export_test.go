@@ -0,0 +1,67 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisExportImport(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "exportcache", ProviderName: "redis1"}))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "importcache", ProviderName: "redis1"}))
+	src := mgr.Cache("exportcache").(Cache)
+	dst := mgr.Cache("importcache").(Cache)
+
+	type sample struct {
+		Name string
+	}
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, src.Put(fmt.Sprintf("key%d", i), i, 20*time.Second))
+	}
+	assert.Nil(t, src.Put("sample", sample{Name: "aah"}, 0))
+	// key of other type within the cache key prefix is skipped
+	assert.Nil(t, src.(*redisCache).client().RPush("exportcache-list1", "value1").Err())
+
+	var buf bytes.Buffer
+	assert.Nil(t, src.Export(&buf))
+	assert.Equal(t, 11, strings.Count(buf.String(), "\n"))
+	assert.False(t, strings.Contains(buf.String(), `"list1"`))
+
+	assert.Nil(t, dst.Import(&buf))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i, dst.Get(fmt.Sprintf("key%d", i)))
+	}
+	assert.Equal(t, sample{Name: "aah"}, dst.Get("sample"))
+	assert.Equal(t, uint64(11), dst.Stats().Puts)
+
+	d, err := dst.TTL("key1")
+	assert.Nil(t, err)
+	assert.True(t, d > 19*time.Second)
+	d, err = dst.TTL("sample")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(-1), d)
+
+	err = dst.Import(strings.NewReader(`{"key": `))
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "aah/cache/importcache: import"))
+
+	assert.Nil(t, src.Flush())
+	assert.Nil(t, dst.Flush())
+}
@@ -0,0 +1,13 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+// dropPut method drops the failed Put of the fail open cache, it's counted as
+// error in the stats and recorded with result `dropped` in the metrics.
+func (r *redisCache) dropPut(k string, err error, start opStart) {
+	r.stats.error()
+	r.record(opPut, k, resultDropped, start, err)
+	r.logFor(opPut, k).warnf("aah/cache/%s: key(%s) put dropped: %v", r.Name(), k, err)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisFailOpen(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6390"
+			timeout {
+				connect = "50ms"
+			}
+			connect {
+				lazy = true
+			}
+			circuit_breaker {
+				enable = true
+				threshold = 2
+				cooldown = "1m"
+			}
+			caches {
+				failopencache {
+					fail_open = true
+				}
+			}
+		}
+	}
+`, &cache.Config{Name: "failopencache", ProviderName: "redis1"}).(Cache)
+	r := c.(*redisCache)
+	assert.True(t, r.failOpen)
+
+	// transport errors and then the open circuit are treated as miss
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, c.Put("fo-key1", "value1", time.Minute))
+		assert.Nil(t, c.Get("fo-key1"))
+		v, err := c.GetE("fo-key1")
+		assert.Nil(t, v)
+		assert.Equal(t, ErrCacheMiss, err)
+	}
+	added, err := c.PutIfAbsent("fo-key1", "value1", time.Minute)
+	assert.Nil(t, err)
+	assert.False(t, added)
+	assert.True(t, c.Stats().Errors > 0)
+
+	// loader value is served without the cache
+	c.SetLoader(func(k string) (interface{}, time.Duration, error) {
+		return "loaded-" + k, time.Minute, nil
+	})
+	assert.Equal(t, "loaded-fo-key2", c.Get("fo-key2"))
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// flushesDB method reports whether Flush uses Redis FLUSHDB. Cache with its
+// own Redis DB is flushed using FLUSHDB only if it's explicitly allowed via
+// the cache config `caches.<cache name>.allow_flushdb = true`, otherwise its
+// keys are deleted by the key prefix same as the cache sharing the DB. FLUSHDB
+// is refused for the provider DB and the DB shared with other cache. So that
+// the misconfigured `db` can't wipe the Redis DB shared with other caches or
+// applications.
+func (r *redisCache) flushesDB() bool {
+	return r.allowFlushDB && r.ownsDB()
+}
+
+// ownsDB method reports true if the cache has its own Redis DB, i.e. it's
+// connected via its own client to the DB other than the provider DB and no
+// other cache of the provider uses the same DB.
+func (r *redisCache) ownsDB() bool {
+	p := r.p
+	if r.cref == p.cref {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if r.db == p.db {
+		return false
+	}
+	for _, c := range p.caches {
+		if c != r && c.db == r.db {
+			return false
+		}
+	}
+	return true
+}
+
+// FlushDryRun method returns the number of keys Flush would delete without
+// deleting them, i.e. Redis DBSIZE if Flush uses FLUSHDB, otherwise the keys
+// with cache key prefix including the metadata keys.
+//
+//	n, err := c.FlushDryRun()
+//	log.Infof("flush deletes %d keys", n)
+func (r *redisCache) FlushDryRun() (int64, error) {
+	var n int64
+	var err error
+	if r.flushesDB() {
+		err = r.forEachShard(func(c Commander) error {
+			size, err := c.DBSize().Result()
+			atomic.AddInt64(&n, size)
+			return err
+		})
+	} else {
+		n, err = r.countKeys(escapeGlob(r.keyPrefix) + "*")
+		if err == nil && r.meta {
+			var m int64
+			m, err = r.countKeys(metaPrefix + escapeGlob(r.keyPrefix) + "*")
+			n += m
+		}
+	}
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		return 0, fmt.Errorf("aah/cache/%s: flush dry-run %v", r.Name(), err)
+	}
+	return n, nil
+}
+
+// DeleteByPatternDryRun method returns the number of cache entries
+// DeleteByPattern would delete for the glob-style pattern without deleting
+// them.
+func (r *redisCache) DeleteByPatternDryRun(glob string) (int64, error) {
+	n, err := r.countKeys(escapeGlob(r.entryPrefix()) + glob)
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		return 0, fmt.Errorf("aah/cache/%s: pattern(%s) dry-run %v", r.Name(), glob, err)
+	}
+	return n, nil
+}
+
+// countKeys method returns the number of keys matching the pattern using
+// SCAN.
+func (r *redisCache) countKeys(pattern string) (int64, error) {
+	var n int64
+	err := r.scanKeys(pattern, func(_ Commander, keys []string) error {
+		atomic.AddInt64(&n, int64(len(keys)))
+		return nil
+	})
+	return n, err
+}
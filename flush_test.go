@@ -0,0 +1,190 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisFlushSafeguard(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				guardcache {
+					db = 3
+				}
+				flushdbcache {
+					db = 6
+					allow_flushdb = true
+				}
+			}
+		}
+	}
+`)
+	for _, name := range []string{"guardcache", "flushdbcache"} {
+		assert.Nil(t, mgr.CreateCache(&cache.Config{Name: name, ProviderName: "redis1"}))
+	}
+	gc := mgr.Cache("guardcache").(*redisCache)
+	fc := mgr.Cache("flushdbcache").(*redisCache)
+	assert.False(t, gc.flushesDB())
+	assert.True(t, fc.flushesDB())
+
+	// key of other application sharing the DB
+	assert.Nil(t, gc.client().Set("foreign-key1", "value", time.Minute).Err())
+	assert.Nil(t, gc.Put("guard-key1", "value1", time.Minute))
+	assert.Nil(t, gc.Put("guard-key2", "value2", time.Minute))
+
+	n, err := gc.FlushDryRun()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+	n, err = gc.DeleteByPatternDryRun("guard-key1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.True(t, gc.Exists("guard-key1"))
+
+	// without allow_flushdb only the cache entries are deleted
+	assert.Nil(t, gc.Flush())
+	assert.False(t, gc.Exists("guard-key1"))
+	assert.Equal(t, int64(1), gc.client().Exists("foreign-key1").Val())
+	assert.Nil(t, gc.client().Del("foreign-key1").Err())
+
+	assert.Nil(t, fc.client().Set("foreign-key1", "value", time.Minute).Err())
+	assert.Nil(t, fc.Put("flushdb-key1", "value1", time.Minute))
+	n, err = fc.FlushDryRun()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Nil(t, fc.Flush())
+	assert.Equal(t, int64(0), fc.client().Exists("foreign-key1").Val())
+
+	assert.Nil(t, mgr.Provider("redis1").(*Provider).Close())
+}
+
+func TestRedisFlushOverlappingPrefix(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "user", ProviderName: "redis1"}))
+	err := mgr.CreateCache(&cache.Config{Name: "user-profile", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "overlaps the key prefix 'user-'")
+	assert.Nil(t, mgr.Provider("redis1").(*Provider).Close())
+
+	mgr = createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				user {
+					key_prefix = "{cache}:"
+				}
+			}
+		}
+	}
+`)
+	for _, name := range []string{"user", "user-profile"} {
+		assert.Nil(t, mgr.CreateCache(&cache.Config{Name: name, ProviderName: "redis1"}))
+	}
+	uc := mgr.Cache("user").(*redisCache)
+	pc := mgr.Cache("user-profile").(*redisCache)
+	assert.Nil(t, uc.Put("key1", "value1", time.Minute))
+	assert.Nil(t, pc.Put("key1", "value1", time.Minute))
+
+	n, err := uc.FlushDryRun()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Nil(t, uc.Flush())
+	assert.False(t, uc.Exists("key1"))
+	assert.True(t, pc.Exists("key1"))
+
+	assert.Nil(t, pc.Flush())
+	assert.Nil(t, mgr.Provider("redis1").(*Provider).Close())
+}
+
+func TestRedisFlushSharedDB(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				shared1 {
+					db = 5
+					allow_flushdb = true
+				}
+				shared2 {
+					db = 5
+					allow_flushdb = true
+				}
+				providerdb {
+					db = 0
+					pool_size = 5
+					allow_flushdb = true
+				}
+			}
+		}
+	}
+`)
+	for _, name := range []string{"shared1", "shared2", "providerdb"} {
+		assert.Nil(t, mgr.CreateCache(&cache.Config{Name: name, ProviderName: "redis1"}))
+	}
+	s1 := mgr.Cache("shared1").(*redisCache)
+	s2 := mgr.Cache("shared2").(*redisCache)
+	pc := mgr.Cache("providerdb").(*redisCache)
+
+	// DB shared with other cache or the provider is not owned by the cache
+	assert.False(t, s1.ownsDB())
+	assert.False(t, s2.ownsDB())
+	assert.False(t, pc.ownsDB())
+	assert.False(t, s1.flushesDB())
+	assert.False(t, pc.flushesDB())
+
+	assert.Nil(t, s1.Put("key1", "value1", time.Minute))
+	assert.Nil(t, s2.Put("key1", "value1", time.Minute))
+	assert.Nil(t, s1.Flush())
+	assert.False(t, s1.Exists("key1"))
+	assert.True(t, s2.Exists("key1"))
+
+	assert.Nil(t, s2.Flush())
+	assert.Nil(t, mgr.Provider("redis1").(*Provider).Close())
+}
+
+func TestRedisFlushProviderAllowFlushDB(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			allow_flushdb = true
+			caches {
+				owndbcache {
+					db = 8
+				}
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "owndbcache", ProviderName: "redis1"}))
+	c := mgr.Cache("owndbcache").(*redisCache)
+
+	// provider level allow_flushdb does not enable FLUSHDB
+	assert.True(t, c.ownsDB())
+	assert.False(t, c.allowFlushDB)
+	assert.False(t, c.flushesDB())
+
+	assert.Nil(t, mgr.Provider("redis1").(*Provider).Close())
+}
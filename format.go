@@ -0,0 +1,87 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// versionedKind is the entry header kind of the entry with the format version.
+const versionedKind = 0xfb
+
+// Migration func type upgrades the stored cache entry b of the format version
+// it's registered for to the next version. Entry b is the encoded entry
+// without the version header, i.e. as written by the previous version of the
+// app, including the compression and encryption if enabled.
+type Migration func(k string, b []byte) ([]byte, error)
+
+// AddMigration method registers the migration of the cache entries from the
+// format version to the next one. Entries are written with the format
+// version of config `format.version` (default 0, no version header), entries
+// of the older versions are upgraded on read by applying the migrations of
+// each version in order, so the codec, compression or entry struct could
+// evolve without invalidating the existing keys. Version without the
+// migration is considered compatible with the next one. Upgraded entries are
+// not written back, Put rewrites them in the current version. Add the
+// migrations before the cache is being used.
+//
+//	// format.version = 2
+//	c.AddMigration(0, upgradeLegacyUser)
+//	c.AddMigration(1, upgradeUserAddress)
+func (r *redisCache) AddMigration(fromVersion int, fn Migration) {
+	if r.migrations == nil {
+		r.migrations = make(map[int]Migration)
+	}
+	r.migrations[fromVersion] = fn
+}
+
+// writeVersion method writes the entry with the version header
+// `0x00<kind><duration>:<version>:` into buf, duration is kept in the header
+// for the Lua scripts.
+func (r *redisCache) writeVersion(buf *bytes.Buffer, d time.Duration, b []byte) {
+	writeHeader(buf, versionedKind, d)
+	var num [20]byte
+	buf.Write(strconv.AppendInt(num[:0], int64(r.formatVersion), 10))
+	buf.WriteByte(':')
+	buf.Write(b)
+}
+
+// migrate method returns the entry b without the version header, upgraded to
+// the current format version. Entry without the version header is version 0.
+func (r *redisCache) migrate(k string, b []byte) ([]byte, error) {
+	version := 0
+	if len(b) > 1 && b[0] == rawMarker && b[1] == versionedKind {
+		idx := bytes.IndexByte(b, ':')
+		if idx < 2 {
+			return nil, errInvalidRawEntry
+		}
+		vidx := bytes.IndexByte(b[idx+1:], ':')
+		if vidx < 1 {
+			return nil, errInvalidRawEntry
+		}
+		var err error
+		if version, err = strconv.Atoi(string(b[idx+1 : idx+1+vidx])); err != nil {
+			return nil, errInvalidRawEntry
+		}
+		b = b[idx+2+vidx:]
+	}
+	if version > r.formatVersion {
+		return nil, fmt.Errorf("entry format version %d is newer than %d", version, r.formatVersion)
+	}
+	for ; version < r.formatVersion; version++ {
+		fn, found := r.migrations[version]
+		if !found {
+			continue
+		}
+		var err error
+		if b, err = fn(k, b); err != nil {
+			return nil, fmt.Errorf("entry format migration from version %d: %v", version, err)
+		}
+	}
+	return b, nil
+}
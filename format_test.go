@@ -0,0 +1,59 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryFormatVersion(t *testing.T) {
+	r0 := &redisCache{}
+	r2 := &redisCache{formatVersion: 2}
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, r2.encode("key1", buf, &entry{D: time.Minute, V: "value1"}))
+	assert.Equal(t, []byte{rawMarker, versionedKind}, buf.Bytes()[:2])
+	assert.True(t, bytes.HasPrefix(buf.Bytes()[2:], []byte("60000000000:2:")))
+	var e entry
+	assert.Nil(t, r2.decode("key1", buf.Bytes(), &e))
+	assert.Equal(t, "value1", e.V)
+	assert.Equal(t, time.Minute, e.D)
+
+	// newer version is not readable by the older app
+	assert.NotNil(t, r0.decode("key1", buf.Bytes(), &e))
+
+	// unversioned entry is version 0, migrated in order
+	old := new(bytes.Buffer)
+	assert.Nil(t, r0.encode("key1", old, &entry{D: time.Minute, V: "legacy"}))
+	var applied []int
+	r2.AddMigration(0, func(k string, b []byte) ([]byte, error) {
+		applied = append(applied, 0)
+		return bytes.Replace(b, []byte("legacy"), []byte("v1"), 1), nil
+	})
+	r2.AddMigration(1, func(k string, b []byte) ([]byte, error) {
+		applied = append(applied, 1)
+		return bytes.Replace(b, []byte("v1"), []byte("v2"), 1), nil
+	})
+	e = entry{}
+	assert.Nil(t, r2.decode("key1", old.Bytes(), &e))
+	assert.Equal(t, "v2", e.V)
+	assert.Equal(t, []int{0, 1}, applied)
+
+	// current version is not migrated
+	applied = nil
+	assert.Nil(t, r2.decode("key1", buf.Bytes(), &e))
+	assert.Nil(t, applied)
+
+	r2.AddMigration(0, func(k string, b []byte) ([]byte, error) {
+		return nil, errors.New("unsupported")
+	})
+	assert.NotNil(t, r2.decode("key1", old.Bytes(), &e))
+	assert.NotNil(t, r2.decode("key1", []byte{rawMarker, versionedKind, '1', ':', 'x'}, &e))
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// generationKey is the Redis key suffix of the cache generation number.
+const generationKey = "generation"
+
+// generation struct holds the cache generation number, it's included in every
+// cache key, i.e. `<cache key prefix>g<generation>:<key>`. Bumping the
+// generation invalidates all the cache entries in O(1), entries of the older
+// generations are left to expire naturally. Generation is read from Redis at
+// most once per refresh interval, so the other instances see the bump within
+// the interval or immediately with the invalidation enabled.
+type generation struct {
+	mu       sync.RWMutex
+	key      string
+	prefix   string
+	interval time.Duration
+	loadedAt time.Time
+}
+
+func (p *Provider) newGeneration(r *redisCache) *generation {
+	return &generation{
+		key:      r.keyPrefix + generationKey,
+		prefix:   r.keyPrefix + "g0:",
+		interval: parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(r.Name(), "generation.refresh_interval"), "1s"), "1s"),
+	}
+}
+
+// InvalidateAll method invalidates all the cache entries by bumping the cache
+// generation, so it's O(1) regardless of the cache size. Entries of the
+// previous generation are not deleted, they expire naturally. It's enabled per
+// cache via config `generation.enable = true`, otherwise it falls back to
+// Flush.
+func (r *redisCache) InvalidateAll() error {
+	if r.gen == nil {
+		return r.Flush()
+	}
+	start := r.begin(opFlush, "")
+	if r.local != nil {
+		r.local.Flush()
+	}
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opFlush, "", ErrCircuitOpen, start)
+		return fmt.Errorf("aah/cache/%s: %v", r.Name(), ErrCircuitOpen)
+	}
+	if r.wb != nil {
+		r.wb.cancelAll()
+	}
+	n, err := r.client().Incr(r.gen.key).Result()
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opFlush, "", err, start)
+		return fmt.Errorf("aah/cache/%s: %v", r.Name(), err)
+	}
+	r.gen.set(r.keyPrefix, n)
+	if r.inv != nil {
+		r.inv.publish("")
+	}
+	r.emit(opFlush, "")
+	r.observe(opFlush, "", resultOK, start)
+	return nil
+}
+
+// entryPrefix method returns the Redis key prefix of the cache entries, i.e.
+// the cache key prefix and the current generation if it's enabled.
+func (r *redisCache) entryPrefix() string {
+	if r.gen == nil {
+		return r.keyPrefix
+	}
+	r.gen.mu.RLock()
+	prefix, fresh := r.gen.prefix, time.Since(r.gen.loadedAt) < r.gen.interval
+	r.gen.mu.RUnlock()
+	if fresh {
+		return prefix
+	}
+
+	n, err := r.client().Get(r.gen.key).Int64()
+	if err = notacacheMiss(err); err != nil {
+		r.logger.errorf("aah/cache/%s: generation %v", r.Name(), err)
+		return prefix
+	}
+	return r.gen.set(r.keyPrefix, n)
+}
+
+func (g *generation) set(keyPrefix string, n int64) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.prefix = keyPrefix + "g" + strconv.FormatInt(n, 10) + ":"
+	g.loadedAt = time.Now()
+	return g.prefix
+}
+
+// expire method forces the generation to be read from Redis on next use.
+func (g *generation) expire() {
+	g.mu.Lock()
+	g.loadedAt = time.Time{}
+	g.mu.Unlock()
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisGenerationInvalidateAll(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				generationcache {
+					generation {
+						enable = true
+						refresh_interval = "100ms"
+					}
+				}
+			}
+		}
+	}
+`, &cache.Config{Name: "generationcache", ProviderName: "redis1"}).(Cache)
+
+	rc := c.(*redisCache)
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, c.Put("key1", "value1", time.Minute))
+	assert.Nil(t, c.Put("key2", "value2", time.Minute))
+	assert.Equal(t, "value1", c.Get("key1"))
+	n, err := c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+
+	oldKey := rc.key("key1")
+	assert.Nil(t, c.InvalidateAll())
+	assert.NotEqual(t, oldKey, rc.key("key1"))
+	assert.Nil(t, c.Get("key1"))
+	assert.False(t, c.Exists("key2"))
+	n, err = c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	// previous generation entries are left to expire
+	d, err := rc.client().PTTL(oldKey).Result()
+	assert.Nil(t, err)
+	assert.True(t, d > 0)
+
+	assert.Nil(t, c.Put("key1", "value3", time.Minute))
+	assert.Equal(t, "value3", c.Get("key1"))
+
+	// generation bumped by other instance is seen after refresh interval
+	assert.Nil(t, rc.client().Incr(rc.gen.key).Err())
+	time.Sleep(150 * time.Millisecond)
+	assert.Nil(t, c.Get("key1"))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisInvalidateAllWithoutGeneration(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "nogenerationcache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("key1", "value1", time.Minute))
+	assert.Nil(t, c.InvalidateAll())
+	assert.False(t, c.Exists("key1"))
+}
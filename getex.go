@@ -0,0 +1,88 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// GetAndExtend method returns the cache entry and sets its expiration to given
+// duration in a single round trip, zero or negative duration removes the
+// expiration, e.g. renewing the token on use. Expiration is subject to the
+// `ttl.*` policy same as Put. It uses Redis `GETEX` and falls back to `GET`
+// and `PEXPIRE` in a transaction on Redis prior to 6.2. Entry header keeps its
+// original duration, so the slide mode and `Touch` reset the expiration to it.
+// It returns `ErrCacheMiss` if the entry does not exists.
+func (r *redisCache) GetAndExtend(k string, d time.Duration) (interface{}, error) {
+	start := r.begin(opGet, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opGet, k, ErrCircuitOpen, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+
+	b, err := r.getEx(r.key(k), r.expiration(k, d))
+	r.p.done(notacacheMiss(err))
+	if err != nil {
+		r.stats.miss()
+		if err = notacacheMiss(err); err == nil {
+			r.observe(opGet, k, resultMiss, start)
+			return nil, ErrCacheMiss
+		}
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.emit(opTTL, k)
+	r.stats.hit()
+
+	var e entry
+	if err = r.decode(k, b, &e); err != nil {
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opGet, k, resultHit, start)
+	if e.V == NotFound {
+		return nil, ErrNotFound
+	}
+	return e.V, nil
+}
+
+// getEx method gets the Redis key and sets its expiration using `GETEX`, once
+// Redis reports the command as unknown the transaction is used thereafter.
+func (r *redisCache) getEx(key string, d time.Duration) ([]byte, error) {
+	if atomic.LoadInt32(&r.p.noGetEx) == 0 {
+		cmd := redis.NewStringCmd("getex", key, "persist")
+		if d > 0 {
+			cmd = redis.NewStringCmd("getex", key, "px", durationMillis(d))
+		}
+		_ = r.client().Process(cmd)
+		v, err := cmd.Bytes()
+		if err == nil || !strings.HasPrefix(strings.ToLower(err.Error()), "err unknown command") {
+			return v, err
+		}
+		atomic.StoreInt32(&r.p.noGetEx, 1)
+	}
+	var get *redis.StringCmd
+	_, err := r.client().TxPipelined(func(pipe redis.Pipeliner) error {
+		get = pipe.Get(key)
+		if d > 0 {
+			pipe.PExpire(key, d)
+		} else {
+			pipe.Persist(key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return get.Bytes()
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisGetAndExtend(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "getexcache", ProviderName: "redis1"}).(Cache)
+
+	for _, fallback := range []int32{0, 1} {
+		atomic.StoreInt32(&c.(*redisCache).p.noGetEx, fallback)
+
+		assert.Nil(t, c.Put("token-1", "value1", 10*time.Second))
+		v, err := c.GetAndExtend("token-1", time.Minute)
+		assert.Nil(t, err)
+		assert.Equal(t, "value1", v)
+		ttl, err := c.TTL("token-1")
+		assert.Nil(t, err)
+		assert.True(t, ttl > 10*time.Second)
+
+		v, err = c.GetAndExtend("token-1", 0)
+		assert.Nil(t, err)
+		assert.Equal(t, "value1", v)
+		ttl, err = c.TTL("token-1")
+		assert.Nil(t, err)
+		assert.True(t, ttl < 0)
+
+		v, err = c.GetAndExtend("token-2", time.Minute)
+		assert.Nil(t, v)
+		assert.Equal(t, ErrCacheMiss, err)
+		assert.False(t, c.Exists("token-2"))
+	}
+
+	assert.Nil(t, c.Flush())
+}
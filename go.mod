@@ -1,7 +1,14 @@
 module aahframe.work/cache/provider/redis
 
+go 1.20
+
 require (
 	aahframe.work v0.12.0
+	github.com/alicebob/miniredis/v2 v2.30.4
 	github.com/go-redis/redis v6.14.1+incompatible
+	github.com/prometheus/client_golang v0.9.2
 	github.com/stretchr/testify v1.2.2
+	go.opentelemetry.io/otel v1.19.0
+	go.opentelemetry.io/otel/trace v1.19.0
+	golang.org/x/sync v0.0.0-20181108010431-42b317875d0f
 )
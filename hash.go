@@ -0,0 +1,273 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// setFieldScript sets the hash field only if the hash exists, so the partial
+// update does not create the entry without expiration.
+var setFieldScript = redis.NewScript(`if redis.call("exists", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("hset", KEYS[1], ARGV[1], ARGV[2])
+return 1`)
+
+var hashFieldsCache sync.Map
+
+// hashField struct holds the struct field index and its hash field name.
+type hashField struct {
+	index int
+	name  string
+}
+
+// PutFields method puts the struct value v as Redis hash with field per
+// exported struct field, it replaces the existing entry. Each field value is
+// encoded individually, so the fields could be read and updated using
+// `GetField` and `SetField` without transferring the whole value. Hash field
+// name defaults to struct field name, it could be customized using tag
+// `redis:"name"` and `redis:"-"` skips the field.
+//
+// Entries stored by `PutFields` are read using `GetFields` or `GetField`,
+// not `Get`.
+//
+//	err := c.PutFields("session-1", &Session{UserID: 1, Theme: "dark"}, time.Hour)
+//	err = c.SetField("session-1", "Theme", "light")
+func (r *redisCache) PutFields(k string, v interface{}, d time.Duration) error {
+	sv := reflect.Indirect(reflect.ValueOf(v))
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("aah/cache/%s: key(%s) struct value expected, got %T", r.Name(), k, v)
+	}
+	start := r.begin(opPut, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opPut, k, ErrCircuitOpen, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+
+	fields := make(map[string]interface{})
+	for _, f := range structHashFields(sv.Type()) {
+		b, err := r.encodeField(k, sv.Field(f.index).Interface())
+		if err != nil {
+			r.stats.error()
+			r.observeError(opPut, k, err, start)
+			return fmt.Errorf("aah/cache/%s: key(%s) field(%s) %v", r.Name(), k, f.name, err)
+		}
+		fields[f.name] = b
+	}
+
+	d = r.expiration(k, d)
+	_, err := r.client().TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Del(r.key(k))
+		if len(fields) > 0 {
+			pipe.HMSet(r.key(k), fields)
+		}
+		if d > 0 {
+			pipe.PExpire(r.key(k), d)
+		}
+		return nil
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.fieldsChanged(k)
+	r.metaPut(k, d)
+	r.stats.put()
+	r.observe(opPut, k, resultOK, start)
+	return nil
+}
+
+// GetFields method decodes the cached hash entry stored by `PutFields` into
+// the struct pointed to by dest. It returns `ErrCacheMiss` if the entry does
+// not exists, fields absent in the hash are left untouched.
+func (r *redisCache) GetFields(k string, dest interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("aah/cache/%s: key(%s) non-nil struct pointer expected, got %T", r.Name(), k, dest)
+	}
+	start := r.begin(opGet, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opGet, k, ErrCircuitOpen, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+
+	var values map[string]string
+	err := r.read(func(c Commander) error {
+		var err error
+		values, err = c.HGetAll(r.key(k)).Result()
+		return err
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	if len(values) == 0 {
+		r.stats.miss()
+		r.observe(opGet, k, resultMiss, start)
+		return ErrCacheMiss
+	}
+
+	sv := dv.Elem()
+	for _, f := range structHashFields(sv.Type()) {
+		b, found := values[f.name]
+		if !found {
+			continue
+		}
+		fv, err := r.decodeField(k, []byte(b))
+		if err == nil && fv != nil {
+			err = assign(sv.Field(f.index), fv)
+		}
+		if err != nil {
+			r.stats.error()
+			r.observeError(opGet, k, err, start)
+			return fmt.Errorf("aah/cache/%s: key(%s) field(%s) %v", r.Name(), k, f.name, err)
+		}
+	}
+	r.stats.hit()
+	r.observe(opGet, k, resultHit, start)
+	return nil
+}
+
+// GetField method returns the value of the hash field from the cached entry
+// stored by `PutFields`. It returns `ErrCacheMiss` if the entry or field does
+// not exists.
+func (r *redisCache) GetField(k, field string) (interface{}, error) {
+	start := r.begin(opGet, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opGet, k, ErrCircuitOpen, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+
+	var b []byte
+	err := r.read(func(c Commander) error {
+		var err error
+		b, err = c.HGet(r.key(k), field).Bytes()
+		return err
+	})
+	r.p.done(notacacheMiss(err))
+	if err != nil {
+		r.stats.miss()
+		if err = notacacheMiss(err); err == nil {
+			r.observe(opGet, k, resultMiss, start)
+			return nil, ErrCacheMiss
+		}
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+
+	v, err := r.decodeField(k, b)
+	if err != nil {
+		r.stats.error()
+		r.stats.miss()
+		r.observeError(opGet, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) field(%s) %v", r.Name(), k, field, err)
+	}
+	r.stats.hit()
+	r.observe(opGet, k, resultHit, start)
+	return v, nil
+}
+
+// SetField method updates the value of the hash field in the cached entry
+// stored by `PutFields`, entry expiration is preserved. It returns
+// `ErrCacheMiss` if the entry does not exists.
+func (r *redisCache) SetField(k, field string, v interface{}) error {
+	start := r.begin(opPut, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opPut, k, ErrCircuitOpen, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	b, err := r.encodeField(k, v)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) field(%s) %v", r.Name(), k, field, err)
+	}
+
+	n, err := setFieldScript.Run(r.client(), []string{r.key(k)}, field, b).Int64()
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opPut, k, resultOK, start)
+	if n == 0 {
+		return ErrCacheMiss
+	}
+	r.fieldsChanged(k)
+	r.stats.put()
+	return nil
+}
+
+// encodeField method encodes the hash field value in the entry format.
+func (r *redisCache) encodeField(k string, v interface{}) ([]byte, error) {
+	registerType(reflect.TypeOf(v))
+	buf := acquireBuffer()
+	defer releaseBuffer(buf)
+	if err := r.encode(k, buf, &entry{V: v}); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), buf.Bytes()...), nil
+}
+
+// decodeField method decodes the hash field value encoded by `encodeField`.
+func (r *redisCache) decodeField(k string, b []byte) (interface{}, error) {
+	var e entry
+	if err := r.decode(k, b, &e); err != nil {
+		return nil, err
+	}
+	return e.V, nil
+}
+
+// fieldsChanged method discards the local copy of the hash entry and notifies
+// the other provider instances.
+func (r *redisCache) fieldsChanged(k string) {
+	if r.local != nil {
+		r.local.Delete(k)
+	}
+	if r.inv != nil {
+		r.inv.publish(k)
+	}
+	r.emit(opPut, k)
+}
+
+// structHashFields method returns the exported fields of the struct type
+// with its hash field name.
+func structHashFields(t reflect.Type) []hashField {
+	if v, found := hashFieldsCache.Load(t); found {
+		return v.([]hashField)
+	}
+	var fields []hashField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		name := sf.Name
+		if tag := sf.Tag.Get("redis"); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		fields = append(fields, hashField{index: i, name: name})
+	}
+	hashFieldsCache.Store(t, fields)
+	return fields
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type sessionFields struct {
+	UserID  int64
+	Theme   string `redis:"theme"`
+	Roles   []string
+	Secret  string `redis:"-"`
+	private string
+}
+
+func TestRedisHashFields(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "hashcache", ProviderName: "redis1"}).(Cache)
+
+	var s sessionFields
+	assert.Equal(t, ErrCacheMiss, c.GetFields("session-1", &s))
+	assert.Equal(t, ErrCacheMiss, c.SetField("session-1", "theme", "light"))
+
+	err := c.PutFields("session-1", &sessionFields{UserID: 1, Theme: "dark",
+		Roles: []string{"admin"}, Secret: "secret", private: "private"}, 10*time.Second)
+	assert.Nil(t, err)
+
+	v, err := c.GetField("session-1", "theme")
+	assert.Nil(t, err)
+	assert.Equal(t, "dark", v)
+	_, err = c.GetField("session-1", "Secret")
+	assert.Equal(t, ErrCacheMiss, err)
+
+	assert.Nil(t, c.SetField("session-1", "theme", "light"))
+	assert.Nil(t, c.GetFields("session-1", &s))
+	assert.Equal(t, sessionFields{UserID: 1, Theme: "light", Roles: []string{"admin"}}, s)
+
+	d, err := c.TTL("session-1")
+	assert.Nil(t, err)
+	assert.True(t, d > 9*time.Second)
+
+	// replaces the entry
+	assert.Nil(t, c.PutFields("session-1", sessionFields{UserID: 2}, 0))
+	s = sessionFields{}
+	assert.Nil(t, c.GetFields("session-1", &s))
+	assert.Equal(t, sessionFields{UserID: 2}, s)
+
+	assert.NotNil(t, c.PutFields("session-2", "not a struct", 0))
+	assert.NotNil(t, c.GetFields("session-1", s))
+
+	assert.Nil(t, c.Delete("session-1"))
+	assert.False(t, c.Exists("session-1"))
+	assert.Nil(t, c.Flush())
+}
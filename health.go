@@ -0,0 +1,109 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// HealthStatus struct holds the health information of the Redis provider.
+type HealthStatus struct {
+	// Connected reports whether the last Redis operation was successful.
+	Connected bool
+
+	// LastError is the last error occurred while communicating with Redis.
+	LastError error
+
+	// LastErrorTime is the time of the last error.
+	LastErrorTime time.Time
+
+	// Reconnects is the count of connectivity restorations after failures.
+	Reconnects uint64
+}
+
+// HealthCheck method checks Redis server availability using PING with the
+// timeout of config `health_check.timeout`, default is `1s`. It's suitable
+// for aah application health endpoints.
+func (p *Provider) HealthCheck() error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- p.client().Ping().Err() }()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-time.After(p.healthCheckTimeout):
+		err = fmt.Errorf("health check timed out after %s", p.healthCheckTimeout)
+	}
+	p.health.record(err)
+	if err != nil {
+		return fmt.Errorf("aah/cache/%s: %v", p.name, err)
+	}
+	return nil
+}
+
+// HealthStatus method returns the health information of the provider.
+func (p *Provider) HealthStatus() HealthStatus {
+	return p.health.status()
+}
+
+// PoolStats method returns the connection pool statistics of the client if
+// supported otherwise empty stats.
+func (p *Provider) PoolStats() *redis.PoolStats {
+	if ps, ok := p.client().(interface{ PoolStats() *redis.PoolStats }); ok {
+		return ps.PoolStats()
+	}
+	return &redis.PoolStats{}
+}
+
+// done method records the outcome of the Redis operation for health and
+// circuit breaker.
+func (p *Provider) done(err error) {
+	p.health.record(err)
+	p.cb.done(err)
+}
+
+type health struct {
+	mu            sync.RWMutex
+	failing       bool
+	lastErr       error
+	lastErrTime   time.Time
+	reconnects    uint64
+	everConnected bool
+}
+
+func (h *health) record(err error) {
+	h.mu.RLock()
+	changed := (err != nil) != h.failing || (err == nil && !h.everConnected)
+	h.mu.RUnlock()
+	if err == nil && !changed {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err != nil {
+		h.failing, h.lastErr, h.lastErrTime = true, err, time.Now()
+		return
+	}
+	if h.failing {
+		h.reconnects++
+	}
+	h.failing, h.everConnected = false, true
+}
+
+func (h *health) status() HealthStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return HealthStatus{
+		Connected:     h.everConnected && !h.failing,
+		LastError:     h.lastErr,
+		LastErrorTime: h.lastErrTime,
+		Reconnects:    h.reconnects,
+	}
+}
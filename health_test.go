@@ -0,0 +1,74 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisHealthCheck(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.Nil(t, p.HealthCheck())
+
+	hs := p.HealthStatus()
+	assert.True(t, hs.Connected)
+	assert.Nil(t, hs.LastError)
+	assert.Equal(t, uint64(0), hs.Reconnects)
+
+	ps := p.PoolStats()
+	assert.NotNil(t, ps)
+	assert.True(t, ps.TotalConns > 0)
+}
+
+func TestRedisHealthCheckFailure(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6390"
+			connect {
+				lazy = true
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.NotNil(t, p.HealthCheck())
+
+	hs := p.HealthStatus()
+	assert.False(t, hs.Connected)
+	assert.NotNil(t, hs.LastError)
+	assert.False(t, hs.LastErrorTime.IsZero())
+}
+
+func TestHealthRecord(t *testing.T) {
+	var h health
+	assert.False(t, h.status().Connected)
+
+	h.record(nil)
+	assert.True(t, h.status().Connected)
+
+	h.record(errors.New("connection refused"))
+	hs := h.status()
+	assert.False(t, hs.Connected)
+	assert.Equal(t, errors.New("connection refused"), hs.LastError)
+
+	h.record(nil)
+	h.record(nil)
+	hs = h.status()
+	assert.True(t, hs.Connected)
+	assert.Equal(t, uint64(1), hs.Reconnects)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Hook interface is used to instrument the cache operations at the cache
+// semantics level, e.g. StatsD, Datadog or SLO tracking. Operation names are
+// `get`, `put`, `delete`, `exists`, `ttl` and `flush`. Cache miss is not an
+// error. Hooks are invoked synchronously on the caller goroutine, keep them
+// cheap.
+type Hook interface {
+	// BeforeOp method is called before the cache operation is started.
+	BeforeOp(cacheName, op, key string)
+
+	// AfterOp method is called after the cache operation is completed with its
+	// duration and error.
+	AfterOp(cacheName, op, key string, d time.Duration, err error)
+}
+
+// AddHook method registers the hook to instrument the cache operations of the
+// provider. Register it before the caches are created.
+//
+//	p := aah.App().CacheManager().Provider("redis1").(*redis.Provider)
+//	p.AddHook(statsdHook)
+func (p *Provider) AddHook(h Hook) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hooks = append(p.hooks, h)
+}
+
+// begin method notifies the hooks the start of the cache operation and returns
+// the start time.
+func (r *redisCache) begin(op, k string) opStart {
+	return r.beginCtx(context.Background(), op, k)
+}
+
+// beginCtx method is same as `begin`, the span of the operation is started as
+// a child of the span carried by ctx.
+func (r *redisCache) beginCtx(ctx context.Context, op, k string) opStart {
+	span := r.p.startSpan(ctx, r.Name(), op, k)
+	for _, h := range r.p.hooks {
+		h.BeforeOp(r.Name(), op, k)
+	}
+	return opStart{Time: time.Now(), span: span}
+}
+
+func (r *redisCache) afterOp(op, k string, start time.Time, err error) {
+	if len(r.p.hooks) == 0 {
+		return
+	}
+	d := time.Since(start)
+	for _, h := range r.p.hooks {
+		h.AfterOp(r.Name(), op, k, d, err)
+	}
+}
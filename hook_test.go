@@ -0,0 +1,81 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingHook struct {
+	mu     sync.Mutex
+	before []string
+	after  []string
+}
+
+func (h *recordingHook) BeforeOp(cacheName, op, key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.before = append(h.before, cacheName+" "+op+" "+key)
+}
+
+func (h *recordingHook) AfterOp(cacheName, op, key string, d time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.after = append(h.after, fmt.Sprintf("%s %s %s %v %v", cacheName, op, key, d > 0, err))
+}
+
+func TestRedisHooks(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	h := &recordingHook{}
+	mgr.Provider("redis1").(*Provider).AddHook(h)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "hookcache", ProviderName: "redis1"}))
+	c := mgr.Cache("hookcache")
+
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	assert.Equal(t, "value1", c.Get("key1"))
+	assert.Nil(t, c.Get("notexists"))
+	assert.True(t, c.Exists("key1"))
+	assert.Nil(t, c.Delete("key1"))
+	assert.Nil(t, c.Flush())
+
+	assert.Equal(t, []string{
+		"hookcache put key1",
+		"hookcache get key1",
+		"hookcache get notexists",
+		"hookcache exists key1",
+		"hookcache delete key1",
+		"hookcache flush ",
+	}, h.before)
+	assert.Equal(t, []string{
+		"hookcache put key1 true <nil>",
+		"hookcache get key1 true <nil>",
+		"hookcache get notexists true <nil>",
+		"hookcache exists key1 true <nil>",
+		"hookcache delete key1 true <nil>",
+		"hookcache flush  true <nil>",
+	}, h.after)
+
+	// error is reported
+	_, err := c.(Cache).PutIfVersion("key1", "value1", "", 10*time.Second)
+	assert.Nil(t, err)
+	h.after = nil
+	_ = c.(Cache).Touch("key1")
+	assert.NotNil(t, c.(Cache).SetField("key1", "field1", "v"))
+	assert.Contains(t, h.after[1], "WRONGTYPE")
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,69 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PFAdd method adds the elements to the HyperLogLog of the cache for given
+// key and reports whether its approximated cardinality changed. HyperLogLog
+// is created without expiration if it does not exists, use `Expire` to set
+// it. HyperLogLog keys share the cache key prefix, they are removed by
+// `Flush` and are not readable using `Get`.
+//
+//	// unique visitors per day
+//	_, err := c.PFAdd("visitors-2018-10-16", visitorID)
+//	n, err := c.PFCount("visitors-2018-10-16")
+func (r *redisCache) PFAdd(k string, elements ...interface{}) (bool, error) {
+	start := r.begin(opPut, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opPut, k, ErrCircuitOpen, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	n, err := r.client().PFAdd(r.key(k), elements...).Result()
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opPut, k, resultOK, start)
+	return n == 1, nil
+}
+
+// PFCount method returns the approximated number of unique elements added to
+// the HyperLogLog of the cache for given keys, multiple keys return the
+// cardinality of their union. It returns zero if the keys do not exists. In
+// cluster mode the keys must hash to the same slot, e.g. `{visitors}-day1`.
+func (r *redisCache) PFCount(keys ...string) (int64, error) {
+	k := strings.Join(keys, ",")
+	start := r.begin(opGet, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opGet, k, ErrCircuitOpen, start)
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	rkeys := make([]string, len(keys))
+	for i, key := range keys {
+		rkeys[i] = r.key(key)
+	}
+	var n int64
+	err := r.read(func(c Commander) error {
+		var err error
+		n, err = c.PFCount(rkeys...).Result()
+		return err
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opGet, k, resultOK, start)
+	return n, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisHyperLogLog(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "hllcache", ProviderName: "redis1"}).(Cache)
+
+	changed, err := c.PFAdd("visitors-day1", "u1", "u2", "u3")
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	changed, err = c.PFAdd("visitors-day1", "u1")
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	_, err = c.PFAdd("visitors-day2", "u3", "u4")
+	assert.Nil(t, err)
+	assert.Nil(t, c.Expire("visitors-day2", time.Minute))
+
+	n, err := c.PFCount("visitors-day1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+	n, err = c.PFCount("visitors-day1", "visitors-day2")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), n)
+	n, err = c.PFCount("not-exists")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	assert.Nil(t, c.Flush())
+	n, err = c.PFCount("visitors-day1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis"
+)
+
+// invalidator struct publishes cache invalidation messages on Put, Delete and
+// Flush to the per cache Redis Pub/Sub channel and subscribes to the same
+// channel, so that the local cache layer and registered callbacks of other
+// provider instances can react to the changes.
+//
+// Channel is configured via `invalidation.channel`, default is
+// `aah:cache:invalidate:<cache key prefix>`. Message format is
+// `<instance id> <key>`, empty key means all the cache entries are
+// invalidated (Flush).
+type invalidator struct {
+	r         *redisCache
+	channel   string
+	pubsub    *redis.PubSub
+	mu        sync.RWMutex
+	callbacks []func(key string)
+}
+
+func newInvalidator(r *redisCache, channel string) (*invalidator, error) {
+	inv := &invalidator{r: r, channel: channel}
+	inv.pubsub = r.p.client().Subscribe(channel)
+
+	// subscription gets established in the background on connection restore
+	if r.p.Connected() {
+		if _, err := inv.pubsub.Receive(); err != nil {
+			_ = inv.pubsub.Close()
+			return nil, err
+		}
+	}
+	go inv.listen(inv.pubsub.Channel())
+	return inv, nil
+}
+
+func (inv *invalidator) publish(k string) {
+	if err := inv.r.p.client().Publish(inv.channel, inv.r.p.id+" "+k).Err(); err != nil {
+		inv.r.logFor(opPut, k).errorf("aah/cache/%s: invalidation publish key(%s) %v", inv.r.Name(), k, err)
+	}
+}
+
+func (inv *invalidator) onInvalidate(fn func(key string)) {
+	inv.mu.Lock()
+	inv.callbacks = append(inv.callbacks, fn)
+	inv.mu.Unlock()
+}
+
+func (inv *invalidator) listen(ch <-chan *redis.Message) {
+	for msg := range ch {
+		idx := strings.IndexByte(msg.Payload, ' ')
+		if idx == -1 || msg.Payload[:idx] == inv.r.p.id {
+			continue
+		}
+		inv.invalidate(msg.Payload[idx+1:])
+	}
+}
+
+func (inv *invalidator) invalidate(k string) {
+	if len(k) == 0 && inv.r.gen != nil {
+		inv.r.gen.expire()
+	}
+	if inv.r.local != nil {
+		if len(k) == 0 {
+			inv.r.local.Flush()
+		} else {
+			inv.r.local.Delete(k)
+		}
+	}
+
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+	for _, fn := range inv.callbacks {
+		fn(k)
+	}
+}
+
+// resubscribe method re-establishes the subscription on the current provider
+// client, e.g. after `Reload`.
+func (inv *invalidator) resubscribe() error {
+	prev := inv.pubsub
+	inv.pubsub = inv.r.p.client().Subscribe(inv.channel)
+	go inv.listen(inv.pubsub.Channel())
+	return prev.Close()
+}
+
+func (inv *invalidator) close() error {
+	return inv.pubsub.Close()
+}
+
+func newInstanceID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
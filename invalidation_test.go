@@ -0,0 +1,66 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisInvalidation(t *testing.T) {
+	cfgStr := `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			local {
+				enable = true
+			}
+		}
+	}
+`
+	c1 := createTestCache(t, "redis1", cfgStr, &cache.Config{Name: "invcache", ProviderName: "redis1"}).(Cache)
+	c2 := createTestCache(t, "redis1", cfgStr, &cache.Config{Name: "invcache", ProviderName: "redis1"}).(Cache)
+
+	keys := make(chan string, 10)
+	c2.OnInvalidate(func(key string) { keys <- key })
+
+	assert.Nil(t, c2.Put("inv-key1", "value1", 10*time.Second))
+	assert.Equal(t, "value1", c2.Get("inv-key1"))
+
+	assert.Nil(t, c1.Put("inv-key1", "value2", 10*time.Second))
+	assert.Equal(t, "inv-key1", waitForKey(t, keys))
+	assert.Equal(t, "value2", c2.Get("inv-key1"))
+
+	assert.Nil(t, c1.Delete("inv-key1"))
+	assert.Equal(t, "inv-key1", waitForKey(t, keys))
+	assert.Nil(t, c2.Get("inv-key1"))
+
+	assert.Nil(t, c1.Flush())
+	assert.Equal(t, "", waitForKey(t, keys))
+
+	// own changes are not delivered to own callbacks
+	assert.Nil(t, c2.Put("inv-key2", "value2", 10*time.Second))
+	select {
+	case k := <-keys:
+		t.Errorf("unexpected invalidation for key %s", k)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.Nil(t, c2.Flush())
+}
+
+func waitForKey(t *testing.T, keys chan string) string {
+	select {
+	case k := <-keys:
+		return k
+	case <-time.After(2 * time.Second):
+		t.Error("invalidation message not received")
+		return "<timeout>"
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+	"unicode"
+)
+
+// hashedKeyMarker is the prefix of the hashed cache key, so that the hashed
+// keys are distinguishable from the readable keys.
+const hashedKeyMarker = "#"
+
+// keyHasher struct hashes the cache keys longer than the threshold or
+// containing whitespace or control characters, short keys are kept readable.
+type keyHasher struct {
+	newHash   func() hash.Hash
+	threshold int
+}
+
+func (p *Provider) newKeyHasher(cacheName string) (*keyHasher, error) {
+	algo := p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "key.hash"), "")
+	kh := &keyHasher{threshold: p.appCfg.IntDefault(p.cacheCfgKey(cacheName, "key.hash_threshold"), 128)}
+	switch algo {
+	case "":
+		return nil, nil
+	case "sha256":
+		kh.newHash = sha256.New
+	case "sha1":
+		kh.newHash = sha1.New
+	default:
+		return nil, fmt.Errorf("unsupported key.hash '%s'", algo)
+	}
+	return kh, nil
+}
+
+// hash method returns the key as is or hashed, tenant scope of the key is kept
+// readable, so that the tenant entries could be matched by prefix. Key starting
+// with the hashed key marker is always hashed, so it can't collide with the
+// hashed form of another key.
+func (kh *keyHasher) hash(k string) string {
+	var scope string
+	if strings.HasPrefix(k, tenantKeyPrefix) {
+		if idx := strings.IndexByte(k[len(tenantKeyPrefix):], ':'); idx >= 0 {
+			scope, k = k[:len(tenantKeyPrefix)+idx+1], k[len(tenantKeyPrefix)+idx+1:]
+		}
+	}
+	if len(k) <= kh.threshold && !unsafeKey(k) && !strings.HasPrefix(k, hashedKeyMarker) {
+		return scope + k
+	}
+	h := kh.newHash()
+	_, _ = h.Write([]byte(k))
+	return scope + hashedKeyMarker + hex.EncodeToString(h.Sum(nil))
+}
+
+// key method returns the Redis key of the cache entry, i.e. the cache key
+// prefix, the generation if it's enabled and the key, hashed if the key
+// hashing is enabled via `key.hash`.
+func (r *redisCache) key(k string) string {
+	if r.kh == nil {
+		return r.entryPrefix() + k
+	}
+	return r.entryPrefix() + r.kh.hash(k)
+}
+
+func unsafeKey(k string) bool {
+	for _, c := range k {
+		if unicode.IsSpace(c) || unicode.IsControl(c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisKeyHash(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			key {
+				hash = "sha256"
+				hash_threshold = 32
+			}
+		}
+	}
+`, &cache.Config{Name: "hashcache", ProviderName: "redis1"}).(*redisCache)
+
+	assert.Equal(t, "hashcache-short", c.key("short"))
+	longKey := "https://aahframework.org/docs/cache?query=" + strings.Repeat("x", 100)
+	assert.True(t, strings.HasPrefix(c.key(longKey), "hashcache-#"))
+	assert.Len(t, c.key(longKey), len("hashcache-#")+64)
+	assert.Equal(t, c.key(longKey), c.key(longKey))
+	assert.True(t, strings.HasPrefix(c.key("with space"), "hashcache-#"))
+	assert.True(t, strings.HasPrefix(c.key("new\nline"), "hashcache-#"))
+
+	// key with the marker can't collide with the hashed form of another key
+	hashed := c.kh.hash(longKey)
+	assert.NotEqual(t, hashed, c.kh.hash(hashed))
+	assert.True(t, strings.HasPrefix(c.key("#short"), "hashcache-#"))
+	assert.Len(t, c.key("#short"), len("hashcache-#")+64)
+
+	assert.Nil(t, c.Put(longKey, "value1", 10*time.Second))
+	assert.Equal(t, "value1", c.Get(longKey))
+	assert.True(t, c.Exists(longKey))
+	assert.Nil(t, c.Delete(longKey))
+	assert.False(t, c.Exists(longKey))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisKeyHashInvalidConfig(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			key.hash = "md4"
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "hashcache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/hashcache: unsupported key.hash 'md4'", err.Error())
+}
+
+func TestRedisKeyPrefixTemplate(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	name = "myapp"
+	env {
+		active = "prod"
+	}
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			namespace = "ns-"
+			key_prefix = "{app}:{env}:{provider}:{cache}:"
+			caches {
+				nscache {
+					key_prefix = "{namespace}{cache}/"
+				}
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "tmplcache", ProviderName: "redis1"}))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "nscache", ProviderName: "redis1"}))
+	c := mgr.Cache("tmplcache").(*redisCache)
+	assert.Equal(t, "myapp:prod:redis1:tmplcache:", c.keyPrefix)
+	assert.Equal(t, "ns-nscache/", mgr.Cache("nscache").(*redisCache).keyPrefix)
+
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	v, err := c.client().Exists("myapp:prod:redis1:tmplcache:key1").Result()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), v)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisKeyPrefixInvalid(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				emptycache {
+					key_prefix = "{namespace}"
+				}
+				dupcache1 {
+					key_prefix = "{app}:shared:"
+				}
+				dupcache2 {
+					key_prefix = "{app}:shared:"
+				}
+			}
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "emptycache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "key prefix must not be empty")
+
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "dupcache1", ProviderName: "redis1"}))
+	err = mgr.CreateCache(&cache.Config{Name: "dupcache2", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "of cache 'dupcache1'")
+
+	assert.Nil(t, mgr.Provider("redis1").(*Provider).Close())
+}
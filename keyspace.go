@@ -0,0 +1,91 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis"
+)
+
+// keyspaceEvents are the Redis keyspace notification events observed by the
+// eviction callbacks.
+var keyspaceEvents = []string{"expired", "evicted", "del"}
+
+// evictionListener struct subscribes to the Redis keyspace notifications
+// `__keyevent@<db>__:expired`, `:evicted` and `:del` and calls the registered
+// callbacks for the cache entries. Redis server publishes the notifications
+// only if it's enabled via `notify-keyspace-events` config, e.g. `Egxe`.
+type evictionListener struct {
+	r         *redisCache
+	channels  []string
+	pubsub    *redis.PubSub
+	mu        sync.RWMutex
+	callbacks []func(key string)
+}
+
+func newEvictionListener(r *redisCache, db int, configure bool) (*evictionListener, error) {
+	if configure {
+		if err := r.client().ConfigSet("notify-keyspace-events", "Egxe").Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	el := &evictionListener{r: r, channels: make([]string, 0, len(keyspaceEvents))}
+	for _, event := range keyspaceEvents {
+		el.channels = append(el.channels, "__keyevent@"+strconv.Itoa(db)+"__:"+event)
+	}
+	el.pubsub = r.client().Subscribe(el.channels...)
+
+	// subscription gets established in the background on connection restore
+	if r.p.Connected() {
+		if _, err := el.pubsub.Receive(); err != nil {
+			_ = el.pubsub.Close()
+			return nil, err
+		}
+	}
+	go el.listen(el.pubsub.Channel())
+	return el, nil
+}
+
+func (el *evictionListener) onEvicted(fn func(key string)) {
+	el.mu.Lock()
+	el.callbacks = append(el.callbacks, fn)
+	el.mu.Unlock()
+}
+
+func (el *evictionListener) listen(ch <-chan *redis.Message) {
+	for msg := range ch {
+		prefix := el.r.entryPrefix()
+		if !strings.HasPrefix(msg.Payload, prefix) {
+			continue
+		}
+		k := strings.TrimPrefix(msg.Payload, prefix)
+		if el.r.local != nil {
+			el.r.local.Delete(k)
+		}
+
+		el.mu.RLock()
+		for _, fn := range el.callbacks {
+			fn(k)
+		}
+		el.mu.RUnlock()
+	}
+}
+
+// resubscribe method re-establishes the subscription on the current cache
+// client, e.g. after `Reload`.
+func (el *evictionListener) resubscribe() error {
+	prev := el.pubsub
+	el.pubsub = el.r.client().Subscribe(el.channels...)
+	go el.listen(el.pubsub.Channel())
+	return prev.Close()
+}
+
+func (el *evictionListener) close() error {
+	return el.pubsub.Close()
+}
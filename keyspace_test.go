@@ -0,0 +1,73 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisOnEvicted(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			keyspace_events {
+				enable = true
+				configure = true
+			}
+		}
+	}
+`, &cache.Config{Name: "evictcache", ProviderName: "redis1"}).(Cache)
+
+	keys := make(chan string, 10)
+	c.OnEvicted(func(key string) { keys <- key })
+
+	assert.Nil(t, c.Put("key1", "value1", 100*time.Millisecond))
+	// expired event is published when the key is accessed or by active expire cycle
+	time.Sleep(200 * time.Millisecond)
+	assert.False(t, c.Exists("key1"))
+	assert.Equal(t, "key1", waitForKey(t, keys))
+
+	assert.Nil(t, c.Put("key2", "value2", 10*time.Second))
+	assert.Nil(t, c.Delete("key2"))
+	assert.Equal(t, "key2", waitForKey(t, keys))
+
+	// other cache entries are not observed
+	other := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "othercache", ProviderName: "redis1"})
+	assert.Nil(t, other.Put("key3", "value3", 10*time.Second))
+	assert.Nil(t, other.Delete("key3"))
+	select {
+	case k := <-keys:
+		t.Errorf("unexpected eviction callback for key(%s)", k)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisOnEvictedNotEnabled(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "evictcache", ProviderName: "redis1"}).(Cache)
+	c.OnEvicted(func(key string) {})
+	assert.Nil(t, c.(*redisCache).el)
+}
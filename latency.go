@@ -0,0 +1,106 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencySamples is the number of the recent latency samples kept per
+// operation for the percentiles.
+const latencySamples = 1024
+
+// Latency struct holds the latency percentiles of the cache operation
+// computed over its recent samples, Count is the total number of samples
+// recorded.
+type Latency struct {
+	Count uint64
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+// LatencyStats struct holds the latency percentiles of Get, Put and Delete
+// operations of the cache instance, including the time spent in the app side
+// (encoding, compression, encryption), so compare them with the Redis
+// `SLOWLOG` or `LATENCY` reports to find out where the time is spent. It's
+// tracked internally, independent of the metrics config.
+type LatencyStats struct {
+	Get    Latency
+	Put    Latency
+	Delete Latency
+}
+
+// latencyRing struct keeps the recent latency samples of the operation in the
+// fixed size ring buffer.
+type latencyRing struct {
+	mu      sync.Mutex
+	count   uint64
+	samples [latencySamples]time.Duration
+}
+
+func (l *latencyRing) add(d time.Duration) {
+	l.mu.Lock()
+	l.samples[l.count%latencySamples] = d
+	l.count++
+	l.mu.Unlock()
+}
+
+func (l *latencyRing) percentiles() Latency {
+	l.mu.Lock()
+	n := l.count
+	if n > latencySamples {
+		n = latencySamples
+	}
+	samples := make([]time.Duration, n)
+	copy(samples, l.samples[:n])
+	lat := Latency{Count: l.count}
+	l.mu.Unlock()
+	if n == 0 {
+		return lat
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	lat.P50 = percentile(samples, 50)
+	lat.P95 = percentile(samples, 95)
+	lat.P99 = percentile(samples, 99)
+	return lat
+}
+
+// percentile method returns the nearest-rank percentile of the sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	idx := (len(sorted)*p+99)/100 - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+// cacheLatency struct tracks the latency of Get, Put and Delete operations.
+type cacheLatency struct {
+	get    latencyRing
+	put    latencyRing
+	delete latencyRing
+}
+
+func (c *cacheLatency) observe(op string, d time.Duration) {
+	switch op {
+	case opGet:
+		c.get.add(d)
+	case opPut:
+		c.put.add(d)
+	case opDelete:
+		c.delete.add(d)
+	}
+}
+
+func (c *cacheLatency) snapshot() LatencyStats {
+	return LatencyStats{
+		Get:    c.get.percentiles(),
+		Put:    c.put.percentiles(),
+		Delete: c.delete.percentiles(),
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisLatencyPercentiles(t *testing.T) {
+	l := &latencyRing{}
+	assert.Equal(t, Latency{}, l.percentiles())
+
+	for i := 1; i <= 100; i++ {
+		l.add(time.Duration(i) * time.Millisecond)
+	}
+	lat := l.percentiles()
+	assert.Equal(t, uint64(100), lat.Count)
+	assert.Equal(t, 50*time.Millisecond, lat.P50)
+	assert.Equal(t, 95*time.Millisecond, lat.P95)
+	assert.Equal(t, 99*time.Millisecond, lat.P99)
+
+	// ring keeps only the recent samples
+	for i := 0; i < latencySamples; i++ {
+		l.add(time.Second)
+	}
+	lat = l.percentiles()
+	assert.Equal(t, uint64(100+latencySamples), lat.Count)
+	assert.Equal(t, time.Second, lat.P50)
+	assert.Equal(t, time.Second, lat.P99)
+}
+
+func TestRedisLatencyStats(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "latencycache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("latency-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("latency-key1"))
+	assert.Nil(t, c.Get("latency-key2"))
+	assert.Nil(t, c.Delete("latency-key1"))
+
+	lat := c.Stats().Latency
+	assert.Equal(t, uint64(2), lat.Get.Count)
+	assert.Equal(t, uint64(1), lat.Put.Count)
+	assert.Equal(t, uint64(1), lat.Delete.Count)
+	assert.True(t, lat.Get.P50 > 0)
+	assert.True(t, lat.Get.P99 >= lat.Get.P50)
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"aahframe.work/cache"
+)
+
+// Cache entry storage layouts.
+const (
+	layoutNative = "native"
+	layoutJSON   = "json"
+)
+
+// newLayout method returns the storage layout of the cache configured via
+// `format.layout`, values are `native` (default) and `json`.
+//
+// Native layout writes the entry header with its expiration duration followed
+// by the raw or gob encoded value, so it's readable only by this package.
+//
+// JSON layout writes the value as plain JSON without header, expiration lives
+// only in Redis key TTL, so that the services written in other languages could
+// read and write the same cache entries. Values read are generic JSON values,
+// i.e. `map[string]interface{}`, `[]interface{}`, string, float64, bool and
+// nil, use `GetInto` to decode into the typed value. Entries stored in native
+// layout remain readable. It's not supported with the encryption, `compress`
+// oversize policy, `format.version` and slide eviction mode, since those need
+// the entry header.
+func (p *Provider) newLayout(r *redisCache) (string, error) {
+	layout := p.appCfg.StringDefault(p.cacheCfgKey(r.Name(), "format.layout"), layoutNative)
+	switch layout {
+	case layoutNative:
+	case layoutJSON:
+		if r.aead != nil || r.oversizePolicy == oversizeCompress || r.formatVersion > 0 ||
+			r.cfg.EvictionMode == cache.EvictionModeSlide {
+			return "", fmt.Errorf("format.layout '%s' is not supported with encryption, compress oversize policy, format.version or slide eviction mode", layout)
+		}
+	default:
+		return "", fmt.Errorf("unsupported format.layout '%s'", layout)
+	}
+	return layout, nil
+}
+
+// encodeJSON method writes the cache entry value into buf as plain JSON. Not
+// found entry of the negative caching is written in native layout, since it
+// has no JSON representation.
+func encodeJSON(buf *bytes.Buffer, e *entry) error {
+	if e.V == NotFound {
+		return encodeEntry(buf, e)
+	}
+	b, err := json.Marshal(e.V)
+	if err != nil {
+		return err
+	}
+	_, err = buf.Write(b)
+	return err
+}
+
+// decodeJSON method reads the cache entry value from the plain JSON b.
+func decodeJSON(b []byte, e *entry) error {
+	return json.Unmarshal(b, &e.V)
+}
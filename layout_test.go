@@ -0,0 +1,81 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisJSONLayout(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				jsoncache {
+					format {
+						layout = "json"
+					}
+					negative_ttl = "1m"
+				}
+				badcache {
+					format {
+						layout = "xml"
+					}
+				}
+				encjsoncache {
+					format {
+						layout = "json"
+					}
+					encryption {
+						enable = true
+						key = "MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDE="
+					}
+				}
+			}
+		}
+	}
+`)
+	assert.NotNil(t, mgr.CreateCache(&cache.Config{Name: "badcache", ProviderName: "redis1"}))
+	assert.NotNil(t, mgr.CreateCache(&cache.Config{Name: "encjsoncache", ProviderName: "redis1"}))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "jsoncache", ProviderName: "redis1"}))
+	c := mgr.Cache("jsoncache").(Cache)
+	r := c.(*redisCache)
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	assert.Nil(t, c.Put("user-1", user{Name: "jeeva", Age: 30}, time.Minute))
+	raw, err := r.client().Get(r.key("user-1")).Result()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name":"jeeva","age":30}`, raw)
+	ttl, err := c.TTL("user-1")
+	assert.Nil(t, err)
+	assert.True(t, ttl > 0)
+
+	assert.Equal(t, map[string]interface{}{"name": "jeeva", "age": float64(30)}, c.Get("user-1"))
+	var u user
+	assert.Nil(t, c.GetInto("user-1", &u))
+	assert.Equal(t, user{Name: "jeeva", Age: 30}, u)
+
+	// written by other language service
+	assert.Nil(t, r.client().Set(r.key("tags"), `["a","b"]`, time.Minute).Err())
+	var tags []string
+	assert.Nil(t, c.GetInto("tags", &tags))
+	assert.Equal(t, []string{"a", "b"}, tags)
+	assert.Nil(t, r.client().Set(r.key("name"), `"aah"`, time.Minute).Err())
+	assert.Equal(t, "aah", c.Get("name"))
+
+	c.SetLoader(func(k string) (interface{}, time.Duration, error) { return nil, 0, nil })
+	assert.Equal(t, NotFound, c.Get("user-2"))
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Loader func type loads the value and its expiration for given key from
+// backing store on cache miss. Returning nil value means entry does not exists
+// in the backing store, it's not cached.
+type Loader func(key string) (interface{}, time.Duration, error)
+
+// SetLoader method sets the read-through loader of the cache, so that Get
+// transparently populates the cache misses. Set the loader before the cache
+// is being used. Loader calls are coalesced when the stampede protection is
+// enabled.
+func (r *redisCache) SetLoader(fn Loader) {
+	r.loader = fn
+}
+
+func (r *redisCache) load(ctx context.Context, k string) interface{} {
+	load := func() (interface{}, time.Duration, error) { return r.loader(k) }
+
+	var v interface{}
+	var err error
+	if r.sp != nil {
+		v, _, err = r.getOrPutProtected(ctx, k, load)
+	} else {
+		v, err = r.loadAndPut(ctx, k, load)
+	}
+	if err != nil {
+		r.logFor(opGet, k).errorf("aah/cache/%s: key(%s) loader %v", r.Name(), k, err)
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisLoader(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "loadercache", ProviderName: "redis1"}).(Cache)
+
+	var calls int32
+	c.SetLoader(func(key string) (interface{}, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		switch key {
+		case "notfound":
+			return nil, 0, nil
+		case "failure":
+			return nil, 0, errors.New("backend failure")
+		}
+		return "loaded-" + key, 10 * time.Second, nil
+	})
+
+	assert.Equal(t, "loaded-key1", c.Get("key1"))
+	assert.Equal(t, "loaded-key1", c.Get("key1"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.True(t, c.Exists("key1"))
+
+	assert.Nil(t, c.Get("notfound"))
+	assert.False(t, c.Exists("notfound"))
+	assert.Nil(t, c.Get("failure"))
+
+	// GetOrPut stores the given value, loader not used
+	v, err := c.GetOrPut("key2", "value2", 10*time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "value2", v)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisLoaderWithStampedeProtection(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			stampede_protection = true
+		}
+	}
+`, &cache.Config{Name: "loadersfcache", ProviderName: "redis1"}).(Cache)
+
+	var calls int32
+	c.SetLoader(func(key string) (interface{}, time.Duration, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		return "loaded-" + key, 10 * time.Second, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "loaded-key1", c.Get("key1"))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	assert.Nil(t, c.Flush())
+}
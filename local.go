@@ -0,0 +1,104 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// localCache struct is the in-process (L1) cache layer in front of Redis. It is
+// bounded by number of entries (least recently used entry gets evicted) and
+// TTL. It's enabled per cache via config `local.enable = true`.
+//
+// Note: Local cache holds the value as-is, so modifying the value returned by
+// Get modifies the locally cached value too.
+type localCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	ll         *list.List
+	entries    map[string]*list.Element
+}
+
+type localEntry struct {
+	k         string
+	v         interface{}
+	expiresAt time.Time
+}
+
+func newLocalCache(maxEntries int, ttl time.Duration) *localCache {
+	return &localCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (l *localCache) Get(k string) (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, found := l.entries[k]
+	if !found {
+		return nil, false
+	}
+	e := el.Value.(*localEntry)
+	if time.Now().After(e.expiresAt) {
+		l.remove(el)
+		return nil, false
+	}
+	l.ll.MoveToFront(el)
+	return e.v, true
+}
+
+// Put method adds the entry into local cache, expiration is the lesser of
+// local cache TTL and given duration.
+func (l *localCache) Put(k string, v interface{}, d time.Duration) {
+	ttl := l.ttl
+	if d > 0 && d < ttl {
+		ttl = d
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, found := l.entries[k]; found {
+		e := el.Value.(*localEntry)
+		e.v, e.expiresAt = v, time.Now().Add(ttl)
+		l.ll.MoveToFront(el)
+		return
+	}
+	l.entries[k] = l.ll.PushFront(&localEntry{k: k, v: v, expiresAt: time.Now().Add(ttl)})
+	if l.maxEntries > 0 && l.ll.Len() > l.maxEntries {
+		l.remove(l.ll.Back())
+	}
+}
+
+func (l *localCache) Delete(k string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, found := l.entries[k]; found {
+		l.remove(el)
+	}
+}
+
+func (l *localCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.ll.Len()
+}
+
+func (l *localCache) Flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ll.Init()
+	l.entries = make(map[string]*list.Element)
+}
+
+func (l *localCache) remove(el *list.Element) {
+	l.ll.Remove(el)
+	delete(l.entries, el.Value.(*localEntry).k)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalCache(t *testing.T) {
+	l := newLocalCache(3, time.Minute)
+
+	l.Put("key1", "value1", 0)
+	l.Put("key2", "value2", 0)
+	l.Put("key3", "value3", 0)
+	assert.Equal(t, 3, l.Len())
+
+	v, found := l.Get("key1")
+	assert.True(t, found)
+	assert.Equal(t, "value1", v)
+
+	// key2 is least recently used
+	l.Put("key4", "value4", 0)
+	assert.Equal(t, 3, l.Len())
+	_, found = l.Get("key2")
+	assert.False(t, found)
+
+	l.Put("key1", "value1-updated", 0)
+	v, _ = l.Get("key1")
+	assert.Equal(t, "value1-updated", v)
+
+	l.Delete("key1")
+	_, found = l.Get("key1")
+	assert.False(t, found)
+
+	l.Put("key5", "value5", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	_, found = l.Get("key5")
+	assert.False(t, found)
+
+	l.Flush()
+	assert.Equal(t, 0, l.Len())
+}
+
+func TestRedisTwoTierCache(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				localcache {
+					local {
+						enable = true
+						max_entries = 100
+						ttl = "30s"
+					}
+				}
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+
+	err := mgr.CreateCache(&cache.Config{Name: "localcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("localcache")
+	assert.NotNil(t, c.(*redisCache).local)
+
+	err = mgr.CreateCache(&cache.Config{Name: "nolocalcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	assert.Nil(t, mgr.Cache("nolocalcache").(*redisCache).local)
+
+	assert.Nil(t, c.Put("local-key1", "value1", 10*time.Second))
+
+	// entry served from local cache even after it's removed from Redis directly
+	assert.Nil(t, p.Client().Del("localcache-local-key1").Err())
+	assert.Equal(t, "value1", c.Get("local-key1"))
+
+	assert.Nil(t, c.Delete("local-key1"))
+	assert.Nil(t, c.Get("local-key1"))
+
+	// entry populated into local cache on Redis hit
+	assert.Nil(t, c.Put("local-key2", "value2", 10*time.Second))
+	c.(*redisCache).local.Flush()
+	assert.Equal(t, "value2", c.Get("local-key2"))
+	v, found := c.(*redisCache).local.Get("local-key2")
+	assert.True(t, found)
+	assert.Equal(t, "value2", v)
+
+	assert.Nil(t, c.Flush())
+	assert.Equal(t, 0, c.(*redisCache).local.Len())
+}
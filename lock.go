@@ -0,0 +1,110 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+var (
+	// ErrLockNotAcquired returned by Lock when the lock is held by others.
+	ErrLockNotAcquired = errors.New("aah/cache: lock not acquired")
+
+	// ErrLockNotHeld returned by Unlock and Extend when the lock is not held
+	// anymore, i.e. it's expired or acquired by others.
+	ErrLockNotHeld = errors.New("aah/cache: lock not held")
+)
+
+var (
+	lockReleaseScript = redis.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`)
+
+	lockExtendScript = redis.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0`)
+)
+
+// Unlocker interface is returned by Lock, it's used to release or extend the
+// acquired lock.
+type Unlocker interface {
+	// Unlock method releases the lock if it's still held.
+	Unlock() error
+
+	// Extend method resets the lock lease to given duration if it's still held,
+	// duration must be at least 1ms.
+	Extend(ttl time.Duration) error
+}
+
+// Lock method acquires the distributed lock for given name with lease of ttl,
+// lock gets released automatically after the lease, ttl must be at least 1ms
+// so the lock always expires. It's implemented with Redis `SET NX` with unique
+// token and the lock is released only by its owner.
+// Lock keys are prefixed with config `lock_prefix`, default is `lock-`.
+//
+// It returns `ErrLockNotAcquired` if the lock is held by others.
+func (p *Provider) Lock(name string, ttl time.Duration) (Unlocker, error) {
+	return p.acquireLock(p.lockPrefix+name, ttl)
+}
+
+func (p *Provider) acquireLock(key string, ttl time.Duration) (*lock, error) {
+	if err := checkLockTTL(ttl); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: lock(%s) %v", p.name, key, err)
+	}
+	l := &lock{p: p, key: key, token: newInstanceID()}
+	acquired, err := p.client().SetNX(key, l.token, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: lock(%s) %v", p.name, key, err)
+	}
+	if !acquired {
+		return nil, ErrLockNotAcquired
+	}
+	return l, nil
+}
+
+type lock struct {
+	p     *Provider
+	key   string
+	token string
+}
+
+var _ Unlocker = (*lock)(nil)
+
+func (l *lock) Unlock() error {
+	return l.eval(lockReleaseScript, l.token)
+}
+
+func (l *lock) Extend(ttl time.Duration) error {
+	if err := checkLockTTL(ttl); err != nil {
+		return fmt.Errorf("aah/cache/%s: lock(%s) %v", l.p.name, l.key, err)
+	}
+	return l.eval(lockExtendScript, l.token, durationMillis(ttl))
+}
+
+func (l *lock) eval(script *redis.Script, args ...interface{}) error {
+	n, err := script.Run(l.p.client(), []string{l.key}, args...).Int64()
+	if err != nil {
+		return fmt.Errorf("aah/cache/%s: lock(%s) %v", l.p.name, l.key, err)
+	}
+	if n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// checkLockTTL function returns an error if the lock lease is less than 1ms,
+// Redis would keep such lock forever or delete it right away.
+func checkLockTTL(ttl time.Duration) error {
+	if ttl < time.Millisecond {
+		return fmt.Errorf("ttl must be at least 1ms, got %s", ttl)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisLock(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+
+	l, err := p.Lock("lock1", 2*time.Second)
+	assert.Nil(t, err)
+	assert.NotNil(t, l)
+
+	ttl, err := p.Client().PTTL("lock-lock1").Result()
+	assert.Nil(t, err)
+	assert.True(t, ttl > 0 && ttl <= 2*time.Second)
+
+	_, err = p.Lock("lock1", 2*time.Second)
+	assert.Equal(t, ErrLockNotAcquired, err)
+
+	assert.Nil(t, l.Extend(5*time.Second))
+	ttl, _ = p.Client().PTTL("lock-lock1").Result()
+	assert.True(t, ttl > 2*time.Second)
+
+	assert.Nil(t, l.Unlock())
+	assert.Equal(t, ErrLockNotHeld, l.Unlock())
+	assert.Equal(t, ErrLockNotHeld, l.Extend(time.Second))
+
+	// lock expires after its lease
+	_, err = p.Lock("lock2", 50*time.Millisecond)
+	assert.Nil(t, err)
+	time.Sleep(100 * time.Millisecond)
+	l2, err := p.Lock("lock2", time.Second)
+	assert.Nil(t, err)
+
+	// lease less than 1ms would never expire or expire right away
+	_, err = p.Lock("lock3", 0)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "ttl must be at least 1ms")
+	_, err = p.Lock("lock3", 500*time.Microsecond)
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(0), p.Client().Exists("lock-lock3").Val())
+	err = l2.Extend(500 * time.Microsecond)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "ttl must be at least 1ms")
+	assert.Nil(t, l2.Unlock())
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"aahframe.work/log"
+)
+
+const (
+	levelError = iota + 1
+	levelWarn
+	levelInfo
+	levelDebug
+	levelTrace
+)
+
+var logLevels = map[string]int{
+	"error": levelError,
+	"warn":  levelWarn,
+	"info":  levelInfo,
+	"debug": levelDebug,
+	"trace": levelTrace,
+}
+
+// newCacheLogger method returns the logger of the cache with structured
+// fields `provider` and `cache`. Log level of the cache is configured via
+// `log.level`, i.e. error, warn, info, debug or trace. Messages below the
+// level are discarded by the cache, application log level still applies.
+// Default is the application log level.
+func (p *Provider) newCacheLogger(cacheName string) (*cacheLogger, error) {
+	cl := &cacheLogger{
+		l:     p.logger.WithFields(log.Fields{"provider": p.name, "cache": cacheName}),
+		level: levelTrace,
+	}
+	if lvl := strings.ToLower(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "log.level"), "")); lvl != "" {
+		level, found := logLevels[lvl]
+		if !found {
+			return nil, fmt.Errorf("aah/cache/%s: unsupported log.level '%s'", cacheName, lvl)
+		}
+		cl.level = level
+	}
+	return cl, nil
+}
+
+// logFor method returns the cache logger with structured fields `op` and `key`.
+func (r *redisCache) logFor(op, k string) *cacheLogger {
+	return r.logger.with(log.Fields{"op": op, "key": k})
+}
+
+// logOp method logs the cache operation at debug level with its result and
+// latency, if it's enabled via config `log.operations`. Operation exceeding
+// the config `slow_op_threshold` is logged at warn level.
+func (r *redisCache) logOp(op, k, result string, start time.Time) {
+	if !r.logOps && r.slowOpThreshold <= 0 {
+		return
+	}
+	latency := time.Since(start)
+	l := r.logger.with(log.Fields{
+		"op":      op,
+		"key":     k,
+		"result":  result,
+		"latency": latency,
+	})
+	if r.slowOpThreshold > 0 && latency >= r.slowOpThreshold {
+		l.warnf("aah/cache/%s: slow %s key(%s) %s in %s, exceeds slow_op_threshold %s", r.Name(), op, k, result, latency, r.slowOpThreshold)
+		return
+	}
+	if r.logOps {
+		l.debugf("aah/cache/%s: %s key(%s) %s in %s", r.Name(), op, k, result, latency)
+	}
+}
+
+// cacheLogger struct logs the cache messages with structured fields, it
+// discards the messages below its level.
+type cacheLogger struct {
+	l     log.Loggerer
+	level int
+}
+
+func (cl *cacheLogger) with(fields log.Fields) *cacheLogger {
+	return &cacheLogger{l: cl.l.WithFields(fields), level: cl.level}
+}
+
+func (cl *cacheLogger) errorf(format string, v ...interface{}) {
+	cl.l.Errorf(format, v...)
+}
+
+func (cl *cacheLogger) warnf(format string, v ...interface{}) {
+	if cl.level >= levelWarn {
+		cl.l.Warnf(format, v...)
+	}
+}
+
+func (cl *cacheLogger) infof(format string, v ...interface{}) {
+	if cl.level >= levelInfo {
+		cl.l.Infof(format, v...)
+	}
+}
+
+func (cl *cacheLogger) debugf(format string, v ...interface{}) {
+	if cl.level >= levelDebug {
+		cl.l.Debugf(format, v...)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisCacheLogging(t *testing.T) {
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", new(Provider))
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			log.operations = true
+			caches {
+				quietcache {
+					log.level = "error"
+				}
+				invalidcache {
+					log.level = "verbose"
+				}
+			}
+		}
+	}`)
+	lcfg, _ := config.ParseString(`log {
+		level = "trace"
+	}`)
+	l, _ := log.New(lcfg)
+	buf := new(bytes.Buffer)
+	l.SetWriter(buf)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "logcache", ProviderName: "redis1"}))
+	c := mgr.Cache("logcache")
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	assert.Nil(t, c.Get("notexists"))
+	assert.Contains(t, buf.String(), "aah/cache/logcache: put key(key1) ok")
+	assert.Contains(t, buf.String(), "aah/cache/logcache: get key(notexists) miss")
+
+	// operations below the cache log level are discarded
+	buf.Reset()
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "quietcache", ProviderName: "redis1"}))
+	qc := mgr.Cache("quietcache")
+	assert.Nil(t, qc.Put("key1", "value1", 10*time.Second))
+	assert.Nil(t, qc.Get("notexists"))
+	assert.NotContains(t, buf.String(), "aah/cache/quietcache")
+
+	err := mgr.CreateCache(&cache.Config{Name: "invalidcache", ProviderName: "redis1"})
+	assert.Equal(t, "aah/cache/invalidcache: unsupported log.level 'verbose'", err.Error())
+
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, qc.Flush())
+}
+
+func TestRedisSlowOpLogging(t *testing.T) {
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", new(Provider))
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			slow_op_threshold = "1ns"
+			caches {
+				fastcache {
+					slow_op_threshold = "1m"
+				}
+			}
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	buf := new(bytes.Buffer)
+	l.SetWriter(buf)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "slowcache", ProviderName: "redis1"}))
+	c := mgr.Cache("slowcache")
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	assert.Contains(t, buf.String(), "aah/cache/slowcache: slow put key(key1) ok in")
+	assert.Contains(t, buf.String(), "exceeds slow_op_threshold 1ns")
+
+	buf.Reset()
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "fastcache", ProviderName: "redis1"}))
+	fc := mgr.Cache("fastcache")
+	assert.Nil(t, fc.Put("key1", "value1", 10*time.Second))
+	assert.NotContains(t, buf.String(), "aah/cache/fastcache: slow")
+
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, fc.Flush())
+}
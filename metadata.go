@@ -0,0 +1,128 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// metaPrefix is the key prefix of the companion hash holding the entry
+// metadata, i.e. `meta:<entry key>`. It's kept outside of the cache key
+// prefix, so that the metadata is not counted as cache entry.
+const metaPrefix = "meta:"
+
+// metaHitScript increments the hit count only if the metadata exists, so the
+// hit on the entry stored before enabling the metadata does not create the
+// hash without expiration.
+var metaHitScript = redis.NewScript(`if redis.call("exists", KEYS[1]) == 1 then
+	return redis.call("hincrby", KEYS[1], "hits", 1)
+end
+return 0`)
+
+// EntryInfo struct holds the metadata of the cache entry returned by
+// `Inspect`.
+type EntryInfo struct {
+	// CreatedAt is the time the entry was stored, it's zero if the metadata
+	// is not recorded.
+	CreatedAt time.Time
+
+	// Hits is the number of Gets served by the entry since it's stored.
+	Hits int64
+
+	// TTL is the remaining time to live of the entry, -1 for no expiration.
+	TTL time.Duration
+}
+
+// Inspect method returns the metadata of the cache entry, it's useful for
+// debugging the stale entries. Creation time and hit count are recorded in
+// the companion hash `meta:<entry key>` with the same expiration as the entry
+// when it's enabled via config `metadata.enable = true`. Recording the
+// metadata costs an additional Redis command per Put and Get, so enable it
+// only for debugging. It returns `ErrCacheMiss` if the entry does not exists.
+func (r *redisCache) Inspect(k string) (EntryInfo, error) {
+	var info EntryInfo
+	if r.circuitOpen() {
+		return info, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+
+	var ttl *redis.DurationCmd
+	var meta *redis.SliceCmd
+	_, err := r.client().Pipelined(func(pipe redis.Pipeliner) error {
+		ttl = pipe.PTTL(r.key(k))
+		meta = pipe.HMGet(r.metaKey(k), "created", "hits")
+		return nil
+	})
+	r.p.done(err)
+	if err != nil {
+		return info, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	if info.TTL = ttlValue(ttl.Val()); info.TTL == 0 {
+		return info, ErrCacheMiss
+	}
+
+	values := meta.Val()
+	if s, ok := values[0].(string); ok {
+		if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+			info.CreatedAt = time.Unix(0, ms*int64(time.Millisecond))
+		}
+	}
+	if s, ok := values[1].(string); ok {
+		info.Hits, _ = strconv.ParseInt(s, 10, 64)
+	}
+	return info, nil
+}
+
+// metaKey method returns the Redis key of the entry metadata.
+func (r *redisCache) metaKey(k string) string {
+	return metaPrefix + r.key(k)
+}
+
+// metaPut method records the creation time of the stored entry and resets
+// its hit count. Metadata is best effort, failure is logged.
+func (r *redisCache) metaPut(k string, d time.Duration) {
+	if !r.meta {
+		return
+	}
+	mk := r.metaKey(k)
+	_, err := r.client().Pipelined(func(pipe redis.Pipeliner) error {
+		pipe.HMSet(mk, map[string]interface{}{
+			"created": strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10),
+			"hits":    0,
+		})
+		if d > 0 {
+			pipe.PExpire(mk, d)
+		} else {
+			pipe.Persist(mk)
+		}
+		return nil
+	})
+	if err != nil {
+		r.logFor(opPut, k).warnf("aah/cache/%s: key(%s) metadata %v", r.Name(), k, err)
+	}
+}
+
+// metaHit method increments the hit count of the entry.
+func (r *redisCache) metaHit(k string) {
+	if !r.meta {
+		return
+	}
+	if err := metaHitScript.Run(r.client(), []string{r.metaKey(k)}).Err(); err != nil {
+		r.logFor(opGet, k).warnf("aah/cache/%s: key(%s) metadata %v", r.Name(), k, err)
+	}
+}
+
+// metaDelete method deletes the metadata of the entry.
+func (r *redisCache) metaDelete(k string) {
+	if !r.meta {
+		return
+	}
+	if err := r.client().Del(r.metaKey(k)).Err(); err != nil {
+		r.logFor(opDelete, k).warnf("aah/cache/%s: key(%s) metadata %v", r.Name(), k, err)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisEntryMetadata(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			metadata {
+				enable = true
+			}
+		}
+	}
+`, &cache.Config{Name: "metacache", ProviderName: "redis1"}).(Cache)
+
+	before := time.Now().Add(-time.Second)
+	assert.Nil(t, c.Put("key1", "value1", time.Minute))
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "value1", c.Get("key1"))
+	}
+
+	info, err := c.Inspect("key1")
+	assert.Nil(t, err)
+	assert.True(t, info.CreatedAt.After(before) && !info.CreatedAt.After(time.Now()))
+	assert.Equal(t, int64(3), info.Hits)
+	assert.True(t, info.TTL > 50*time.Second && info.TTL <= time.Minute)
+
+	// put resets the metadata
+	assert.Nil(t, c.Put("key1", "value2", 0))
+	info, err = c.Inspect("key1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), info.Hits)
+	assert.Equal(t, time.Duration(-1), info.TTL)
+
+	// metadata is not counted as entry
+	n, err := c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+
+	assert.Nil(t, c.Delete("key1"))
+	_, err = c.Inspect("key1")
+	assert.Equal(t, ErrCacheMiss, err)
+	assert.Equal(t, int64(0), c.(*redisCache).client().Exists(c.(*redisCache).metaKey("key1")).Val())
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisInspectWithoutMetadata(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "nometacache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("key1", "value1", time.Minute))
+	assert.Equal(t, "value1", c.Get("key1"))
+	info, err := c.Inspect("key1")
+	assert.Nil(t, err)
+	assert.True(t, info.CreatedAt.IsZero())
+	assert.Equal(t, int64(0), info.Hits)
+	assert.True(t, info.TTL > 0)
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Cache operation names used in metrics labels.
+const (
+	opGet    = "get"
+	opPut    = "put"
+	opDelete = "delete"
+	opExists = "exists"
+	opTTL    = "ttl"
+	opFlush  = "flush"
+)
+
+// Cache operation results used in metrics labels.
+const (
+	resultOK      = "ok"
+	resultHit     = "hit"
+	resultMiss    = "miss"
+	resultError   = "error"
+	resultDropped = "dropped"
+)
+
+var latencyBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1}
+
+// metrics struct holds the Prometheus collectors of the Redis provider. Metrics
+// are enabled via config `cache.<name>.metrics.enable = true`.
+type metrics struct {
+	ops     *prometheus.CounterVec
+	latency *prometheus.HistogramVec
+	pool    []prometheus.Collector
+}
+
+func newMetrics(p *Provider, cfgPrefix string) (*metrics, error) {
+	namespace := p.appCfg.StringDefault(cfgPrefix+"metrics.namespace", "aah")
+	subsystem := p.appCfg.StringDefault(cfgPrefix+"metrics.subsystem", "cache")
+	constLabels := prometheus.Labels{"provider": p.name}
+
+	m := &metrics{
+		ops: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "operations_total",
+			Help:        "Total number of cache operations by cache, operation and result.",
+			ConstLabels: constLabels,
+		}, []string{"cache", "operation", "result"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "operation_duration_seconds",
+			Help:        "Latency of cache operations in seconds by cache and operation.",
+			ConstLabels: constLabels,
+			Buckets:     latencyBuckets,
+		}, []string{"cache", "operation"}),
+	}
+
+	var err error
+	if m.ops, err = registerCounterVec(m.ops); err != nil {
+		return nil, err
+	}
+	if m.latency, err = registerHistogramVec(m.latency); err != nil {
+		return nil, err
+	}
+
+	poolStats := map[string]func() float64{
+		"pool_total_conns": func() float64 { return float64(p.PoolStats().TotalConns) },
+		"pool_idle_conns":  func() float64 { return float64(p.PoolStats().IdleConns) },
+		"pool_stale_conns": func() float64 { return float64(p.PoolStats().StaleConns) },
+		"pool_hits":        func() float64 { return float64(p.PoolStats().Hits) },
+		"pool_misses":      func() float64 { return float64(p.PoolStats().Misses) },
+		"pool_timeouts":    func() float64 { return float64(p.PoolStats().Timeouts) },
+	}
+	for name, fn := range poolStats {
+		g := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        name,
+			Help:        "Redis connection pool statistics: " + name + ".",
+			ConstLabels: constLabels,
+		}, fn)
+		if err = registerFunc(g); err != nil {
+			return nil, err
+		}
+		m.pool = append(m.pool, g)
+	}
+
+	retries := prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace:   namespace,
+		Subsystem:   subsystem,
+		Name:        "retries_total",
+		Help:        "Total number of Redis read commands retried on transient failure.",
+		ConstLabels: constLabels,
+	}, func() float64 { return float64(p.Retries()) })
+	if err = registerFunc(retries); err != nil {
+		return nil, err
+	}
+	m.pool = append(m.pool, retries)
+
+	return m, nil
+}
+
+// observe method records the cache operation count and latency. It's safe to
+// call on nil metrics, in that case it does nothing.
+func (m *metrics) observe(cacheName, op, result string, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.ops.WithLabelValues(cacheName, op, result).Inc()
+	m.latency.WithLabelValues(cacheName, op).Observe(time.Since(start).Seconds())
+}
+
+// unregister method removes the Prometheus collectors of the provider.
+func (m *metrics) unregister() {
+	if m == nil {
+		return
+	}
+	prometheus.DefaultRegisterer.Unregister(m.ops)
+	prometheus.DefaultRegisterer.Unregister(m.latency)
+	for _, c := range m.pool {
+		prometheus.DefaultRegisterer.Unregister(c)
+	}
+	m.pool = nil
+}
+
+// registerFunc function registers the gauge or counter func collector. Already
+// registered collector, e.g. of the provider not closed before re-create, is
+// replaced, so that the func reads the stats of the current provider.
+func registerFunc(c prometheus.Collector) error {
+	err := prometheus.DefaultRegisterer.Register(c)
+	if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		prometheus.DefaultRegisterer.Unregister(are.ExistingCollector)
+		err = prometheus.DefaultRegisterer.Register(c)
+	}
+	return err
+}
+
+func registerCounterVec(c *prometheus.CounterVec) (*prometheus.CounterVec, error) {
+	if err := prometheus.DefaultRegisterer.Register(c); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector.(*prometheus.CounterVec), nil
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func registerHistogramVec(h *prometheus.HistogramVec) (*prometheus.HistogramVec, error) {
+	if err := prometheus.DefaultRegisterer.Register(h); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector.(*prometheus.HistogramVec), nil
+		}
+		return nil, err
+	}
+	return h, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisMetrics(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			metrics {
+				enable = true
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.NotNil(t, p.metrics)
+
+	err := mgr.CreateCache(&cache.Config{Name: "metricscache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("metricscache")
+
+	assert.Nil(t, c.Put("metrics-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("metrics-key1"))
+	assert.Nil(t, c.Get("metrics-key2"))
+	assert.True(t, c.Exists("metrics-key1"))
+	assert.Nil(t, c.Delete("metrics-key1"))
+
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	assert.Nil(t, err)
+	names := map[string]bool{}
+	for _, mf := range mfs {
+		names[mf.GetName()] = true
+	}
+	assert.True(t, names["aah_cache_operations_total"])
+	assert.True(t, names["aah_cache_operation_duration_seconds"])
+	assert.True(t, names["aah_cache_pool_total_conns"])
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisMetricsDisabled(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.Nil(t, p.metrics)
+
+	// nil metrics is safe to use
+	p.metrics.observe("cache1", opGet, resultHit, time.Now())
+}
+
+func TestRedisMetricsReplaceFunc(t *testing.T) {
+	opts := prometheus.GaugeOpts{Namespace: "aah", Subsystem: "cache", Name: "test_replace_func",
+		ConstLabels: prometheus.Labels{"provider": "redis1"}}
+	old := prometheus.NewGaugeFunc(opts, func() float64 { return 1 })
+	assert.Nil(t, registerFunc(old))
+
+	// provider re-created without Close, gauge reads the new provider
+	cur := prometheus.NewGaugeFunc(opts, func() float64 { return 2 })
+	assert.Nil(t, registerFunc(cur))
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	assert.Nil(t, err)
+	var v float64
+	for _, mf := range mfs {
+		if mf.GetName() == "aah_cache_test_replace_func" {
+			v = mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	assert.Equal(t, float64(2), v)
+
+	m := &metrics{pool: []prometheus.Collector{cur},
+		ops:     prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_ops"}, []string{"cache"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_latency"}, []string{"cache"})}
+	_, _ = registerCounterVec(m.ops)
+	_, _ = registerHistogramVec(m.latency)
+	m.unregister()
+	assert.False(t, prometheus.DefaultRegisterer.Unregister(cur))
+	assert.False(t, prometheus.DefaultRegisterer.Unregister(m.ops))
+	assert.False(t, prometheus.DefaultRegisterer.Unregister(m.latency))
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"time"
+)
+
+// notFoundKind is the entry header kind of the negative cache entry.
+const notFoundKind = 0xfc
+
+// ErrNotFound returned by GetE when the key is negatively cached, i.e. it's
+// known to not exist in the backing store.
+var ErrNotFound = errors.New("aah/cache: not found")
+
+// NotFound is the value returned by Get for the negatively cached key.
+//
+//	switch v := c.Get("user-1"); v {
+//	case nil:
+//		// cache miss
+//	case redis.NotFound:
+//		// known to not exist, skip the backing store lookup
+//	}
+var NotFound interface{} = notFound{}
+
+type notFound struct{}
+
+// PutNotFound method caches the "not found" result for given key with
+// expiration of config `negative_ttl`, default is `1m`. Get returns `NotFound`
+// and GetE returns `ErrNotFound` for the key until it expires or replaced by
+// Put. Exists reports true for the key.
+//
+// When `negative_ttl` is configured, nil value returned by the loader is
+// cached as "not found" result.
+func (r *redisCache) PutNotFound(k string) error {
+	d := r.negativeTTL
+	if d <= 0 {
+		d = time.Minute
+	}
+	return r.put(k, &entry{D: d, V: NotFound})
+}
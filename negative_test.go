@@ -0,0 +1,75 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisNegativeCaching(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			negative_ttl = "2s"
+		}
+	}
+`, &cache.Config{Name: "negativecache", ProviderName: "redis1"}).(Cache)
+
+	var loads int32
+	c.SetLoader(func(k string) (interface{}, time.Duration, error) {
+		atomic.AddInt32(&loads, 1)
+		if k == "user-1" {
+			return "aah", time.Minute, nil
+		}
+		return nil, 0, nil
+	})
+
+	assert.Equal(t, "aah", c.Get("user-1"))
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, NotFound, c.Get("user-2"))
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&loads))
+
+	v, err := c.GetE("user-2")
+	assert.Nil(t, v)
+	assert.Equal(t, ErrNotFound, err)
+	assert.True(t, c.Exists("user-2"))
+
+	d, err := c.TTL("user-2")
+	assert.Nil(t, err)
+	assert.True(t, d > time.Second && d <= 2*time.Second)
+
+	// replaced by put
+	assert.Nil(t, c.Put("user-2", "cache", time.Minute))
+	assert.Equal(t, "cache", c.Get("user-2"))
+
+	assert.Nil(t, c.PutNotFound("user-3"))
+	var s string
+	assert.Equal(t, ErrNotFound, c.GetInto("user-3", &s))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestEncodeDecodeNotFoundEntry(t *testing.T) {
+	buf := new(bytes.Buffer)
+	assert.Nil(t, encodeEntry(buf, &entry{D: time.Minute, V: NotFound}))
+	assert.Equal(t, "\x00\xfc60000000000:", buf.String())
+
+	var e entry
+	assert.Nil(t, decodeEntry(buf.Bytes(), &e))
+	assert.Equal(t, entry{D: time.Minute, V: NotFound}, e)
+
+	for _, b := range []string{"\x00\xfc", "\x00\xfc:", "\x00\xfc60:x"} {
+		assert.Equal(t, errInvalidRawEntry, decodeEntry([]byte(b), &e))
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-redis/redis"
+)
+
+// OnConnect method registers the callback, it's invoked on every new Redis
+// connection after the authentication and `CLIENT SETNAME`. Connection is
+// discarded if the callback returns an error. Register it before the provider
+// gets initialized, it's not applied to the client supplied via
+// `ProviderWithClient`.
+func (p *Provider) OnConnect(fn func(conn *redis.Conn) error) {
+	p.onConnects = append(p.onConnects, fn)
+}
+
+// newOnConnect method returns the initializer of the new connection, it
+// authenticates the connection and selects the given DB if auth is configured,
+// sets the connection name and invokes the registered callbacks. It uses
+// given connection settings rather than the provider fields, so the client
+// being verified on `Reload` doesn't depend on the uncommitted settings.
+func (p *Provider) newOnConnect(cs connSettings, db int) func(*redis.Conn) error {
+	auth := cs.auth(p)
+	if !auth && len(cs.clientName) == 0 && len(p.onConnects) == 0 {
+		return nil
+	}
+	var authenticate func(*redis.Conn) error
+	if auth {
+		authenticate = p.authOnConnect(cs, db)
+	}
+	return func(conn *redis.Conn) error {
+		if authenticate != nil {
+			if err := authenticate(conn); err != nil {
+				return err
+			}
+		}
+		if len(cs.clientName) > 0 {
+			if err := conn.ClientSetName(cs.clientName).Err(); err != nil {
+				return fmt.Errorf("client setname %v", err)
+			}
+		}
+		for _, fn := range p.onConnects {
+			if err := fn(conn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// newClientName method returns the Redis connection name from config
+// `client_name`, default is `<app name>:<provider name>`. Empty value disables
+// it. Whitespaces are replaced with `-`, since Redis does not allow it.
+func (p *Provider) newClientName() string {
+	name, found := p.appCfg.String(p.cfgPrefix + "client_name")
+	if !found {
+		name = p.name
+		if app := p.appCfg.StringDefault("name", ""); len(app) > 0 {
+			name = app + ":" + p.name
+		}
+	}
+	return strings.Join(strings.Fields(name), "-")
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"io/ioutil"
+	"sync/atomic"
+	"testing"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisClientNameAndOnConnect(t *testing.T) {
+	p := new(Provider)
+	var calls int32
+	p.OnConnect(func(conn *redis.Conn) error {
+		atomic.AddInt32(&calls, 1)
+		return conn.Ping().Err()
+	})
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`name = "my app"
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.Equal(t, "my-app:redis1", p.clientName)
+	assert.True(t, atomic.LoadInt32(&calls) > 0)
+	assert.Equal(t, "my-app:redis1", p.Client().ClientGetName().Val())
+	assert.Nil(t, p.Close())
+}
+
+func TestRedisClientNameConfig(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			client_name = "orders-cache"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.Equal(t, "orders-cache", p.Client().ClientGetName().Val())
+	assert.Nil(t, p.Close())
+
+	mgr = createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			client_name = ""
+		}
+	}
+`)
+	p = mgr.Provider("redis1").(*Provider)
+	assert.Nil(t, p.clientOpts.OnConnect)
+	assert.Nil(t, p.Close())
+}
+
+func TestRedisOnConnectError(t *testing.T) {
+	p := new(Provider)
+	p.OnConnect(func(conn *redis.Conn) error {
+		return errors.New("not allowed")
+	})
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	err := mgr.InitProviders(cfg, l)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not allowed")
+}
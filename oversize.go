@@ -0,0 +1,89 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// compressedKind is the entry header kind of the gzip compressed entry.
+const compressedKind = 0xfd
+
+// Oversize policies of the encoded entry exceeding `max_value_size`.
+const (
+	oversizeReject   = "reject"
+	oversizeWarn     = "warn"
+	oversizeCompress = "compress"
+)
+
+// ErrValueTooLarge returned by Put when the encoded entry exceeds the
+// `max_value_size` of the cache.
+var ErrValueTooLarge = errors.New("aah/cache: value too large")
+
+// oversize method applies the oversize policy on the encoded entry. Policy
+// `reject` returns `ErrValueTooLarge`, `warn` logs the warning and `compress`
+// gzip compresses the entry in place, entry still exceeding the limit after
+// compression is rejected.
+func (r *redisCache) oversize(k string, plain *bytes.Buffer, d time.Duration) error {
+	switch r.oversizePolicy {
+	case oversizeWarn:
+		r.logFor(opPut, k).warnf("aah/cache/%s: key(%s) value size %d exceeds max_value_size %d", r.Name(), k, plain.Len(), r.maxValueSize)
+		return nil
+	case oversizeCompress:
+		compressed := acquireBuffer()
+		defer releaseBuffer(compressed)
+		writeHeader(compressed, compressedKind, d)
+		zw := gzip.NewWriter(compressed)
+		if _, err := zw.Write(plain.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		if compressed.Len() <= r.maxValueSize {
+			plain.Reset()
+			_, err := plain.Write(compressed.Bytes())
+			return err
+		}
+	}
+	r.logFor(opPut, k).warnf("aah/cache/%s: key(%s) value size %d exceeds max_value_size %d", r.Name(), k, plain.Len(), r.maxValueSize)
+	return ErrValueTooLarge
+}
+
+func decompress(b []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return ioutil.ReadAll(zr)
+}
+
+// parseSize method parses the size in bytes with optional unit suffix `b`,
+// `kb`, `mb` or `gb`, e.g. `512kb`.
+func parseSize(v string) (int, error) {
+	s, unit := strings.ToLower(strings.TrimSpace(v)), 1
+	for _, u := range []struct {
+		suffix string
+		n      int
+	}{{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30}, {"b", 1}} {
+		if strings.HasSuffix(s, u.suffix) {
+			s, unit = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.n
+			break
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size '%s'", v)
+	}
+	return n * unit, nil
+}
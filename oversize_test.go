@@ -0,0 +1,97 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisMaxValueSize(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			max_value_size = "1kb"
+			caches {
+				warncache {
+					oversize_policy = "warn"
+				}
+				compresscache {
+					oversize_policy = "compress"
+				}
+			}
+		}
+	}
+`)
+	for _, name := range []string{"rejectcache", "warncache", "compresscache"} {
+		assert.Nil(t, mgr.CreateCache(&cache.Config{Name: name, ProviderName: "redis1"}))
+	}
+	large := strings.Repeat("aah cache ", 1000)
+
+	c := mgr.Cache("rejectcache")
+	assert.Nil(t, c.Put("small", "value", 10*time.Second))
+	err := c.Put("large", large, 10*time.Second)
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/rejectcache: key(large) aah/cache: value too large", err.Error())
+	assert.False(t, c.Exists("large"))
+	assert.Nil(t, c.Flush())
+
+	c = mgr.Cache("warncache")
+	assert.Nil(t, c.Put("large", large, 10*time.Second))
+	assert.Equal(t, large, c.Get("large"))
+	assert.Nil(t, c.Flush())
+
+	c = mgr.Cache("compresscache")
+	assert.Nil(t, c.Put("large", large, 10*time.Second))
+	assert.Equal(t, large, c.Get("large"))
+	b, err := c.(*redisCache).client().Get("compresscache-large").Bytes()
+	assert.Nil(t, err)
+	assert.True(t, len(b) <= 1024)
+	assert.Equal(t, byte(compressedKind), b[1])
+	assert.Nil(t, c.(Cache).Touch("large"))
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisMaxValueSizeInvalidConfig(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			max_value_size = "1kb"
+			oversize_policy = "truncate"
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "sizecache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/sizecache: unsupported oversize_policy 'truncate'", err.Error())
+}
+
+func TestParseSize(t *testing.T) {
+	testcases := []struct {
+		value string
+		size  int
+	}{
+		{"512", 512}, {"512b", 512}, {"1kb", 1024}, {"2 MB", 2 << 20}, {"1gb", 1 << 30},
+	}
+	for _, tc := range testcases {
+		size, err := parseSize(tc.value)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.size, size, tc.value)
+	}
+
+	_, err := parseSize("1tb")
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid size '1tb'", err.Error())
+	_, err = parseSize("-1")
+	assert.NotNil(t, err)
+}
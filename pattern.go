@@ -0,0 +1,68 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/go-redis/redis"
+)
+
+// DeleteByPattern method deletes the cache entries matching the glob-style
+// pattern within the cache, e.g. `user:42:*`, and returns the number of
+// deleted entries. Keys are scanned using Redis SCAN and deleted in batches
+// using UNLINK, so the memory is reclaimed in the background by Redis.
+// Pattern is matched against the stored keys, so it does not match the
+// hashed keys. Local caches are flushed, same as Flush.
+func (r *redisCache) DeleteByPattern(glob string) (int64, error) {
+	start := r.begin(opDelete, glob)
+	if r.local != nil {
+		r.local.Flush()
+	}
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opDelete, glob, ErrCircuitOpen, start)
+		return 0, fmt.Errorf("aah/cache/%s: pattern(%s) %v", r.Name(), glob, ErrCircuitOpen)
+	}
+
+	_, cluster := r.client().(*redis.ClusterClient)
+	var deleted int64
+	err := r.scanKeys(escapeGlob(r.entryPrefix())+glob, func(c Commander, keys []string) error {
+		if !cluster {
+			n, err := c.Unlink(keys...).Result()
+			atomic.AddInt64(&deleted, n)
+			return err
+		}
+		// keys of the node could belong to different slots
+		cmds, err := c.Pipelined(func(pipe redis.Pipeliner) error {
+			for _, k := range keys {
+				pipe.Unlink(k)
+			}
+			return nil
+		})
+		for _, cmd := range cmds {
+			atomic.AddInt64(&deleted, cmd.(*redis.IntCmd).Val())
+		}
+		return err
+	})
+	if err == nil && r.meta {
+		err = r.deleteKeys(metaPrefix + escapeGlob(r.entryPrefix()) + glob)
+	}
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opDelete, glob, err, start)
+		return deleted, fmt.Errorf("aah/cache/%s: pattern(%s) %v", r.Name(), glob, err)
+	}
+	if deleted > 0 {
+		if r.inv != nil {
+			r.inv.publish("")
+		}
+		r.emit(opDeletePattern, glob)
+	}
+	r.observe(opDelete, glob, resultOK, start)
+	return deleted, nil
+}
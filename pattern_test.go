@@ -0,0 +1,44 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisDeleteByPattern(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "patterncache", ProviderName: "redis1"}).(Cache)
+
+	for i := 0; i < 2500; i++ {
+		assert.Nil(t, c.Put(fmt.Sprintf("user:42:item:%d", i), i, time.Minute))
+	}
+	assert.Nil(t, c.Put("user:43:item:1", 1, time.Minute))
+	assert.Nil(t, c.Put("user:420", 1, time.Minute))
+
+	n, err := c.DeleteByPattern("user:42:*")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2500), n)
+	assert.False(t, c.Exists("user:42:item:7"))
+	assert.True(t, c.Exists("user:43:item:1"))
+	assert.True(t, c.Exists("user:420"))
+
+	n, err = c.DeleteByPattern("user:42:*")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,198 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// CachePipeliner interface is used to queue the cache operations within
+// `Pipeline` and `Tx`, cache key prefix and the entry encoding are applied
+// same as the cache methods. Queued operations are sent to Redis when the
+// callback returns.
+type CachePipeliner interface {
+	// Get method queues the read of the cache entry, its value is available
+	// after the pipeline is executed.
+	Get(k string) *PipelineValue
+
+	// Put method queues the write of the cache entry with specified expiration.
+	Put(k string, v interface{}, d time.Duration)
+
+	// Delete method queues the delete of the cache entry.
+	Delete(k string)
+
+	// Expire method queues the expiration change of the cache entry, zero or
+	// negative duration removes the expiration.
+	Expire(k string, d time.Duration)
+}
+
+// PipelineValue struct holds the result of the cache entry read queued on
+// `CachePipeliner`.
+type PipelineValue struct {
+	r   *redisCache
+	k   string
+	cmd *redis.StringCmd
+}
+
+// Value method returns the cached entry read by the pipeline. It returns
+// `ErrCacheMiss` if the entry does not exists.
+func (pv *PipelineValue) Value() (interface{}, error) {
+	b, err := pv.cmd.Bytes()
+	if err != nil {
+		if err = notacacheMiss(err); err == nil {
+			return nil, ErrCacheMiss
+		}
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", pv.r.Name(), pv.k, err)
+	}
+	var e entry
+	if err = pv.r.decode(pv.k, b, &e); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", pv.r.Name(), pv.k, err)
+	}
+	return e.V, nil
+}
+
+// Pipeline method queues the cache operations issued within fn and sends them
+// to Redis in one round trip. Operations are not atomic, use `Tx` for atomic
+// multi-key updates. Write-behind and write-through are not applied to the
+// pipelined writes.
+//
+//	var v *redis.PipelineValue
+//	err := c.Pipeline(func(p redis.CachePipeliner) error {
+//		p.Put("key1", "value1", time.Hour)
+//		p.Delete("key2")
+//		v = p.Get("key3")
+//		return nil
+//	})
+func (r *redisCache) Pipeline(fn func(p CachePipeliner) error) error {
+	return r.pipelined(fn, r.client().Pipelined)
+}
+
+// Tx method queues the cache operations issued within fn and executes them
+// atomically using Redis `MULTI`/`EXEC` transaction.
+func (r *redisCache) Tx(fn func(p CachePipeliner) error) error {
+	return r.pipelined(fn, r.client().TxPipelined)
+}
+
+func (r *redisCache) pipelined(fn func(p CachePipeliner) error, exec func(func(redis.Pipeliner) error) ([]redis.Cmder, error)) error {
+	if r.circuitOpen() {
+		r.stats.error()
+		return fmt.Errorf("aah/cache/%s: %v", r.Name(), ErrCircuitOpen)
+	}
+
+	cp := &cachePipeliner{r: r}
+	cmds, err := exec(func(pipe redis.Pipeliner) error {
+		cp.pipe = pipe
+		if err := fn(cp); err != nil {
+			return err
+		}
+		return cp.err
+	})
+	if len(cmds) == 0 {
+		// nothing sent to Redis, i.e. callback failed or queued nothing
+		return err
+	}
+	if r.local != nil {
+		for k := range cp.written {
+			r.local.Delete(k)
+		}
+	}
+	err = nil
+	for _, cmd := range cmds {
+		if err = notacacheMiss(cmd.Err()); err != nil {
+			break
+		}
+	}
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		return fmt.Errorf("aah/cache/%s: %v", r.Name(), err)
+	}
+
+	for k, op := range cp.written {
+		if r.inv != nil {
+			r.inv.publish(k)
+		}
+		r.emit(op, k)
+	}
+	for _, v := range cp.reads {
+		if v.cmd.Err() == nil {
+			r.stats.hit()
+		} else {
+			r.stats.miss()
+		}
+	}
+	for i := 0; i < cp.puts; i++ {
+		r.stats.put()
+	}
+	for i := 0; i < cp.deletes; i++ {
+		r.stats.delete()
+	}
+	return nil
+}
+
+// cachePipeliner struct implements `CachePipeliner` on top of Redis pipeline.
+type cachePipeliner struct {
+	r       *redisCache
+	pipe    redis.Pipeliner
+	err     error
+	reads   []*PipelineValue
+	written map[string]string
+	puts    int
+	deletes int
+}
+
+var _ CachePipeliner = (*cachePipeliner)(nil)
+
+func (cp *cachePipeliner) Get(k string) *PipelineValue {
+	v := &PipelineValue{r: cp.r, k: k, cmd: cp.pipe.Get(cp.r.key(k))}
+	cp.reads = append(cp.reads, v)
+	return v
+}
+
+func (cp *cachePipeliner) Put(k string, v interface{}, d time.Duration) {
+	registerType(reflect.TypeOf(v))
+	d = cp.r.expiration(k, d)
+	e := &entry{D: d, V: v}
+	if d > 0 {
+		e.E = time.Now().Add(d)
+	}
+	buf := acquireBuffer()
+	defer releaseBuffer(buf)
+	if err := cp.r.encode(k, buf, e); err != nil {
+		if cp.err == nil {
+			cp.err = fmt.Errorf("aah/cache/%s: key(%s) %v", cp.r.Name(), k, err)
+		}
+		return
+	}
+	cp.pipe.Set(cp.r.key(k), append([]byte(nil), buf.Bytes()...), d)
+	cp.changed(k, opPut)
+	cp.puts++
+}
+
+func (cp *cachePipeliner) Delete(k string) {
+	cp.pipe.Del(cp.r.key(k))
+	cp.changed(k, opDelete)
+	cp.deletes++
+}
+
+func (cp *cachePipeliner) Expire(k string, d time.Duration) {
+	if d > 0 {
+		cp.pipe.PExpire(cp.r.key(k), d)
+	} else {
+		cp.pipe.Persist(cp.r.key(k))
+	}
+	cp.changed(k, opTTL)
+}
+
+func (cp *cachePipeliner) changed(k, op string) {
+	if cp.written == nil {
+		cp.written = make(map[string]string)
+	}
+	cp.written[k] = op
+}
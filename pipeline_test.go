@@ -0,0 +1,73 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisPipelineAndTx(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "pipelinecache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("key3", "value3", 10*time.Second))
+
+	var v1, v3, v4 *PipelineValue
+	err := c.Pipeline(func(p CachePipeliner) error {
+		p.Put("key1", "value1", 10*time.Second)
+		p.Put("key2", map[string]interface{}{"name": "value2"}, 0)
+		p.Expire("key2", 5*time.Second)
+		v1 = p.Get("key1")
+		v3 = p.Get("key3")
+		v4 = p.Get("key4")
+		return nil
+	})
+	assert.Nil(t, err)
+	v, err := v1.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "value1", v)
+	v, err = v3.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "value3", v)
+	_, err = v4.Value()
+	assert.Equal(t, ErrCacheMiss, err)
+	assert.Equal(t, map[string]interface{}{"name": "value2"}, c.Get("key2"))
+	d, _ := c.TTL("key2")
+	assert.True(t, d > 4*time.Second && d <= 5*time.Second)
+
+	err = c.Tx(func(p CachePipeliner) error {
+		p.Delete("key1")
+		p.Put("key3", 3, 10*time.Second)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.False(t, c.Exists("key1"))
+	assert.Equal(t, 3, c.Get("key3"))
+
+	// callback error discards the queued operations
+	err = c.Tx(func(p CachePipeliner) error {
+		p.Delete("key3")
+		return errors.New("abort")
+	})
+	assert.Equal(t, "abort", err.Error())
+	assert.True(t, c.Exists("key3"))
+
+	// key prefix is applied
+	rc := c.(*redisCache)
+	assert.Equal(t, int64(1), rc.client().Exists(rc.key("key3")).Val())
+
+	assert.Nil(t, c.Flush())
+}
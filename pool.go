@@ -0,0 +1,100 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+// pools struct holds the dedicated clients of the workload classes, so that
+// a burst of slow writes or blocking pops can't exhaust the connections of
+// the latency-sensitive reads.
+//
+// Config `pool.read.size` creates the read pool of given size used by Get,
+// Exists and the other read-only operations of the caches using the provider
+// client. Config `pool.blocking.size` creates the blocking pool used by
+// `BlockingPop` and the blocking reads of the cache event log. Config
+// `pool.write.size` sets the pool size of the provider client used by the
+// writes and the other operations, default is `pool_size`. It's supported in
+// `standalone` and `embedded` modes.
+type pools struct {
+	read     *clientRef
+	blocking *clientRef
+}
+
+func (p *Provider) newPools() *pools {
+	if p.clientOpts == nil {
+		return nil
+	}
+	ps := &pools{}
+	if size := p.appCfg.IntDefault(p.cfgPrefix+"pool.read.size", 0); size > 0 {
+		ps.read = newClientRef(p.newPoolClient(size))
+	}
+	if size := p.appCfg.IntDefault(p.cfgPrefix+"pool.blocking.size", 0); size > 0 {
+		ps.blocking = newClientRef(p.newPoolClient(size))
+	}
+	if ps.read == nil && ps.blocking == nil {
+		return nil
+	}
+	return ps
+}
+
+func (p *Provider) newPoolClient(size int) Commander {
+	opts := *p.clientOpts
+	opts.PoolSize = size
+	return p.newRedisClient(&opts)
+}
+
+// swap method replaces the pool clients with the ones of given pools and
+// returns the previous clients.
+func (ps *pools) swap(nps *pools) []Commander {
+	var stale []Commander
+	for _, refs := range [][2]*clientRef{{ps.read, nps.read}, {ps.blocking, nps.blocking}} {
+		if refs[0] != nil && refs[1] != nil {
+			stale = append(stale, refs[0].load())
+			refs[0].store(refs[1].load())
+		} else if refs[1] != nil {
+			stale = append(stale, refs[1].load())
+		}
+	}
+	return stale
+}
+
+func (ps *pools) close() []string {
+	var errs []string
+	for _, ref := range []*clientRef{ps.read, ps.blocking} {
+		if ref == nil {
+			continue
+		}
+		if err := ref.load().Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return errs
+}
+
+// blockingClient method returns the client of the blocking pool if
+// configured, otherwise the provider client.
+func (p *Provider) blockingClient() Commander {
+	if p.pools != nil && p.pools.blocking != nil {
+		return p.pools.blocking.load()
+	}
+	return p.client()
+}
+
+// blockingClient method returns the client of the blocking pool if
+// configured and the cache uses the provider client, otherwise the cache
+// client.
+func (r *redisCache) blockingClient() Commander {
+	if r.cref == r.p.cref {
+		return r.p.blockingClient()
+	}
+	return r.client()
+}
+
+// readClient method returns the client of the read pool if configured and the
+// cache uses the provider client, otherwise the cache client.
+func (r *redisCache) readClient() Commander {
+	if r.p.pools != nil && r.p.pools.read != nil && r.cref == r.p.cref {
+		return r.p.pools.read.load()
+	}
+	return r.client()
+}
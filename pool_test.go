@@ -0,0 +1,94 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisPartitionedPools(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			pool {
+				read.size = 3
+				write.size = 4
+				blocking.size = 2
+			}
+			reload {
+				drain_timeout = "100ms"
+			}
+			caches {
+				pooldbcache {
+					db = 2
+				}
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.NotNil(t, p.pools)
+	assert.Equal(t, 4, p.clientOpts.PoolSize)
+	assert.Equal(t, 3, p.pools.read.load().(*redis.Client).Options().PoolSize)
+	assert.Equal(t, 2, p.pools.blocking.load().(*redis.Client).Options().PoolSize)
+	assert.True(t, p.blockingClient() != p.client())
+
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "poolcache", ProviderName: "redis1"}))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "pooldbcache", ProviderName: "redis1"}))
+	c := mgr.Cache("poolcache").(*redisCache)
+	assert.True(t, c.readClient() == p.pools.read.load())
+	// cache with own client does not use the pools
+	dc := mgr.Cache("pooldbcache").(*redisCache)
+	assert.True(t, dc.readClient() == dc.client())
+	assert.True(t, dc.blockingClient() == dc.client())
+
+	assert.Nil(t, c.Put("pool-key1", "value1", 10*time.Second))
+	assert.Equal(t, "value1", c.Get("pool-key1"))
+	assert.True(t, c.Exists("pool-key1"))
+
+	_, _, err := p.BlockingPop(100*time.Millisecond, "pool-queue")
+	assert.Equal(t, ErrQueueEmpty, err)
+
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			pool {
+				read.size = 6
+			}
+			reload {
+				drain_timeout = "100ms"
+			}
+		}
+	}`)
+	assert.Nil(t, p.Reload(cfg))
+	assert.Equal(t, 6, p.pools.read.load().(*redis.Client).Options().PoolSize)
+	assert.Equal(t, "value1", c.Get("pool-key1"))
+
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, p.Close())
+}
+
+func TestRedisPartitionedPoolsNotConfigured(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.Nil(t, p.pools)
+	assert.True(t, p.blockingClient() == p.client())
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// ErrQueueEmpty returned by Pop and BlockingPop when the list has no values.
+var ErrQueueEmpty = errors.New("aah/cache: queue empty")
+
+// PushLeft method inserts the values at the head of the list for given name,
+// it returns the length of the list after the push. List keys are prefixed
+// with config `queue_prefix`, default is `queue-`. Values are stored in the
+// cache entry format, so the values are readable by the other aah
+// applications.
+//
+//	// recent items feed
+//	p.PushLeft("recent-orders", orderID)
+//	ids, err := p.ListRange("recent-orders", 0, 9)
+func (p *Provider) PushLeft(name string, values ...interface{}) (int64, error) {
+	return p.push(name, values, p.client().LPush)
+}
+
+// PushRight method inserts the values at the tail of the list for given name,
+// it returns the length of the list after the push. Lists pushed on right and
+// popped using `Pop` or `BlockingPop` work as FIFO queue.
+func (p *Provider) PushRight(name string, values ...interface{}) (int64, error) {
+	return p.push(name, values, p.client().RPush)
+}
+
+// Pop method removes and returns the value at the head of the list for given
+// name. It returns `ErrQueueEmpty` if the list has no values.
+func (p *Provider) Pop(name string) (interface{}, error) {
+	b, err := p.client().LPop(p.queueKey(name)).Bytes()
+	p.done(notacacheMiss(err))
+	if err != nil {
+		if notacacheMiss(err) == nil {
+			return nil, ErrQueueEmpty
+		}
+		return nil, p.queueError(name, err)
+	}
+	return p.queueValue(name, b)
+}
+
+// BlockingPop method removes and returns the value at the head of the first
+// non-empty list of given names, it waits up to timeout for a value. Zero
+// timeout waits indefinitely. It returns the list name of the value and
+// `ErrQueueEmpty` if no value is available within the timeout.
+//
+//	name, v, err := p.BlockingPop(5*time.Second, "jobs-high", "jobs-low")
+func (p *Provider) BlockingPop(timeout time.Duration, names ...string) (string, interface{}, error) {
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = p.queueKey(name)
+	}
+	result, err := p.blockingClient().BLPop(timeout, keys...).Result()
+	p.done(notacacheMiss(err))
+	if err != nil {
+		if notacacheMiss(err) == nil {
+			return "", nil, ErrQueueEmpty
+		}
+		return "", nil, p.queueError(strings.Join(names, ","), err)
+	}
+	name := strings.TrimPrefix(result[0], p.queuePrefix)
+	v, err := p.queueValue(name, []byte(result[1]))
+	return name, v, err
+}
+
+// ListRange method returns the values of the list for given name between
+// start and stop index inclusive without removing them. Negative index
+// counts from the tail, i.e. -1 is the last value.
+func (p *Provider) ListRange(name string, start, stop int64) ([]interface{}, error) {
+	result, err := p.client().LRange(p.queueKey(name), start, stop).Result()
+	p.done(err)
+	if err != nil {
+		return nil, p.queueError(name, err)
+	}
+	values := make([]interface{}, 0, len(result))
+	for _, s := range result {
+		v, err := p.queueValue(name, []byte(s))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func (p *Provider) push(name string, values []interface{}, fn func(string, ...interface{}) *redis.IntCmd) (int64, error) {
+	args := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		registerType(reflect.TypeOf(v))
+		buf := acquireBuffer()
+		if err := encodeEntry(buf, &entry{V: v}); err != nil {
+			releaseBuffer(buf)
+			return 0, p.queueError(name, err)
+		}
+		args = append(args, append([]byte(nil), buf.Bytes()...))
+		releaseBuffer(buf)
+	}
+	n, err := fn(p.queueKey(name), args...).Result()
+	p.done(err)
+	if err != nil {
+		return 0, p.queueError(name, err)
+	}
+	return n, nil
+}
+
+func (p *Provider) queueValue(name string, b []byte) (interface{}, error) {
+	var e entry
+	if err := decodeEntry(b, &e); err != nil {
+		return nil, p.queueError(name, err)
+	}
+	return e.V, nil
+}
+
+func (p *Provider) queueKey(name string) string {
+	return p.queuePrefix + name
+}
+
+func (p *Provider) queueError(name string, err error) error {
+	err = fmt.Errorf("aah/cache/%s: queue(%s) %v", p.name, name, err)
+	p.logger.Error(err)
+	return err
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisQueue(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			queue_prefix = "testq-"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	_ = p.Client().Del("testq-jobs", "testq-recent").Err()
+
+	_, err := p.Pop("jobs")
+	assert.Equal(t, ErrQueueEmpty, err)
+
+	n, err := p.PushRight("jobs", "job1", 2, map[string]interface{}{"id": "job3"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, int64(3), p.Client().LLen("testq-jobs").Val())
+
+	v, err := p.Pop("jobs")
+	assert.Nil(t, err)
+	assert.Equal(t, "job1", v)
+	v, err = p.Pop("jobs")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, v)
+
+	name, v, err := p.BlockingPop(time.Second, "recent", "jobs")
+	assert.Nil(t, err)
+	assert.Equal(t, "jobs", name)
+	assert.Equal(t, map[string]interface{}{"id": "job3"}, v)
+
+	_, _, err = p.BlockingPop(time.Second, "jobs")
+	assert.Equal(t, ErrQueueEmpty, err)
+
+	// recent items feed
+	for _, id := range []string{"o1", "o2", "o3"} {
+		_, err = p.PushLeft("recent", id)
+		assert.Nil(t, err)
+	}
+	values, err := p.ListRange("recent", 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"o3", "o2"}, values)
+
+	_ = p.Client().Del("testq-recent").Err()
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// slidingWindowScript implements sliding window log using sorted set, the
+// members older than window are trimmed before counting. Time is read from
+// Redis server, so the clock skew of app instances does not affect the window.
+var slidingWindowScript = redis.NewScript(`redis.replicate_commands()
+local t = redis.call("time")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+redis.call("zremrangebyscore", KEYS[1], "-inf", now - window)
+local count = redis.call("zcard", KEYS[1])
+if count < limit then
+	redis.call("zadd", KEYS[1], now, ARGV[3])
+	redis.call("pexpire", KEYS[1], window)
+	return {1, limit - count - 1}
+end
+return {0, 0}`)
+
+// RateLimiter struct limits the number of events per identifier (e.g. client
+// IP, API key) within the sliding time window across all the app instances.
+// Create it using `Provider.RateLimiter`.
+type RateLimiter struct {
+	p      *Provider
+	name   string
+	limit  int
+	window time.Duration
+	seq    uint64
+}
+
+// RateLimiter method returns the sliding window rate limiter for given name,
+// it allows up to limit events per identifier within the window. Rate limiter
+// keys are prefixed with config `ratelimit_prefix`, default is `ratelimit-`.
+func (p *Provider) RateLimiter(name string, limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{p: p, name: name, limit: limit, window: window}
+}
+
+// Allow method records the event for given identifier and reports whether
+// it's allowed within the limit and the remaining allowed events in the
+// current window. It returns error if the limit is not positive or the window
+// is less than a millisecond.
+func (rl *RateLimiter) Allow(id string) (bool, int, error) {
+	if rl.limit <= 0 || rl.window < time.Millisecond {
+		return false, 0, fmt.Errorf("aah/cache/%s: ratelimit(%s) invalid limit(%d) or window(%s)",
+			rl.p.name, rl.name, rl.limit, rl.window)
+	}
+	member := rl.p.id + "-" + strconv.FormatUint(atomic.AddUint64(&rl.seq, 1), 10)
+	key := rl.p.rateLimitPrefix + rl.name + "-" + id
+	v, err := slidingWindowScript.Run(rl.p.client(), []string{key},
+		int64(rl.window/time.Millisecond), rl.limit, member).Result()
+	if err != nil {
+		return false, 0, fmt.Errorf("aah/cache/%s: ratelimit(%s) %v", rl.p.name, rl.name, err)
+	}
+
+	result, ok := v.([]interface{})
+	if !ok || len(result) != 2 {
+		return false, 0, fmt.Errorf("aah/cache/%s: ratelimit(%s) unexpected reply %v", rl.p.name, rl.name, v)
+	}
+	allowed, _ := result[0].(int64)
+	remaining, _ := result[1].(int64)
+	return allowed == 1, int(remaining), nil
+}
+
+// Reset method clears the recorded events for given identifier.
+func (rl *RateLimiter) Reset(id string) error {
+	if err := rl.p.client().Del(rl.p.rateLimitPrefix + rl.name + "-" + id).Err(); err != nil {
+		return fmt.Errorf("aah/cache/%s: ratelimit(%s) %v", rl.p.name, rl.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisRateLimiter(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	rl := p.RateLimiter("api", 3, 200*time.Millisecond)
+	assert.Nil(t, rl.Reset("127.0.0.1"))
+
+	for i := 2; i >= 0; i-- {
+		allowed, remaining, err := rl.Allow("127.0.0.1")
+		assert.Nil(t, err)
+		assert.True(t, allowed)
+		assert.Equal(t, i, remaining)
+	}
+
+	allowed, remaining, err := rl.Allow("127.0.0.1")
+	assert.Nil(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 0, remaining)
+
+	// other identifier has its own limit
+	allowed, _, err = rl.Allow("127.0.0.2")
+	assert.Nil(t, err)
+	assert.True(t, allowed)
+
+	// window slides
+	time.Sleep(250 * time.Millisecond)
+	allowed, _, err = rl.Allow("127.0.0.1")
+	assert.Nil(t, err)
+	assert.True(t, allowed)
+
+	assert.Nil(t, rl.Reset("127.0.0.1"))
+	assert.Nil(t, rl.Reset("127.0.0.2"))
+
+	// invalid limit or window
+	for _, invalid := range []*RateLimiter{
+		p.RateLimiter("api", 0, time.Second),
+		p.RateLimiter("api", -1, time.Second),
+		p.RateLimiter("api", 3, 0),
+		p.RateLimiter("api", 3, 500*time.Microsecond),
+	} {
+		allowed, _, err = invalid.Allow("127.0.0.1")
+		assert.NotNil(t, err)
+		assert.False(t, allowed)
+	}
+}
@@ -6,9 +6,15 @@ package redis // import "aahframe.work/cache/provider/redis"
 
 import (
 	"bytes"
+	"context"
+	"crypto/cipher"
 	"encoding/gob"
+	"errors"
 	"fmt"
+	"io"
+	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +23,7 @@ import (
 	"aahframe.work/config"
 	"aahframe.work/log"
 	"github.com/go-redis/redis"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
@@ -25,33 +32,431 @@ import (
 
 // Provider struct represents the Redis cache provider.
 type Provider struct {
-	name       string
-	logger     log.Loggerer
-	cfg        *cache.Config
-	appCfg     *config.Config
-	client     *redis.Client
-	clientOpts *redis.Options
+	retries            uint64 // accessed atomically, first for 64-bit alignment
+	connected          int32
+	noGetDel           int32
+	noGetEx            int32
+	id                 string
+	name               string
+	cfgPrefix          string
+	lockPrefix         string
+	rateLimitPrefix    string
+	queuePrefix        string
+	logger             log.Loggerer
+	appCfg             *config.Config
+	cref               *clientRef
+	clientOpts         *redis.Options
+	username           string
+	password           string
+	db                 int
+	credentials        CredentialsProvider
+	cb                 *breaker
+	health             health
+	healthCheckTimeout time.Duration
+	ownsClient         bool
+	closing            chan struct{}
+	mu                 sync.Mutex
+	closed             bool
+	caches             []*redisCache
+	metrics            *metrics
+	tracer             trace.Tracer
+	warmups            map[string]WarmupFunc
+	keyProvider        KeyProvider
+	replicas           *replicas
+	pools              *pools
+	scripts            map[string]*redis.Script
+	hooks              []Hook
+	clientName         string
+	onConnects         []func(*redis.Conn) error
+	embedded           EmbeddedServer
+	resolver           AddressResolver
+	address            string
+	scan               scanOptions
 }
 
 var _ cache.Provider = (*Provider)(nil)
 
+// ProviderWithClient method returns the Redis cache provider which uses given
+// go-redis client instead of creating new one from config. So that
+// applications could reuse the client they manage with their own pooling,
+// hooks and instrumentation.
+//
+//	aah.App().CacheManager().AddProvider("redis1", redis.ProviderWithClient(client))
+func ProviderWithClient(c redis.UniversalClient) *Provider {
+	return &Provider{cref: newClientRef(c)}
+}
+
 // Init method initializes the Redis cache provider.
 func (p *Provider) Init(providerName string, appCfg *config.Config, logger log.Loggerer) error {
+	p.id = newInstanceID()
+	p.closing = make(chan struct{})
 	p.name = providerName
 	p.appCfg = appCfg
 	p.logger = logger
 
-	cfgPrefix := "cache." + p.name + "."
+	p.cfgPrefix = "cache." + p.name + "."
+	cfgPrefix := p.cfgPrefix
 	if strings.ToLower(p.appCfg.StringDefault(cfgPrefix+"provider", "")) != "redis" {
 		return fmt.Errorf("aah/cache: not a vaild provider name, expected 'redis'")
 	}
 
-	p.clientOpts = &redis.Options{
+	p.lockPrefix = p.appCfg.StringDefault(cfgPrefix+"lock_prefix", "lock-")
+	p.rateLimitPrefix = p.appCfg.StringDefault(cfgPrefix+"ratelimit_prefix", "ratelimit-")
+	p.queuePrefix = p.appCfg.StringDefault(cfgPrefix+"queue_prefix", "queue-")
+	p.healthCheckTimeout = parseDuration(p.appCfg.StringDefault(cfgPrefix+"health_check.timeout", "1s"), "1s")
+	p.scan = p.newScanOptions()
+	if err := p.registerConfigTypes(); err != nil {
+		return fmt.Errorf("aah/cache/%s: %s", p.name, err)
+	}
+	addr := "supplied client"
+	if p.cref == nil {
+		c, opts, cs, caddr, err := p.newClient()
+		if err != nil {
+			return fmt.Errorf("aah/cache/%s: %s", p.name, err)
+		}
+		p.cref = newClientRef(c)
+		p.clientOpts = opts
+		p.setConnSettings(cs)
+		addr = caddr
+		p.ownsClient = true
+		p.replicas = p.newReplicas()
+		p.pools = p.newPools()
+		if opts != nil && p.embedded == nil && p.discoverable(p.address) {
+			go p.watchAddress(p.address)
+		}
+	}
+
+	if err := p.connect(); err != nil {
+		return fmt.Errorf("aah/cache/%s: %w", p.name, err)
+	}
+
+	if p.appCfg.BoolDefault(cfgPrefix+"circuit_breaker.enable", false) {
+		p.cb = &breaker{
+			name:      p.name,
+			logger:    p.logger,
+			threshold: p.appCfg.IntDefault(cfgPrefix+"circuit_breaker.threshold", 5),
+			cooldown:  parseDuration(p.appCfg.StringDefault(cfgPrefix+"circuit_breaker.cooldown", "5s"), "5s"),
+		}
+	}
+
+	if p.appCfg.BoolDefault(cfgPrefix+"metrics.enable", false) {
+		var err error
+		if p.metrics, err = newMetrics(p, cfgPrefix); err != nil {
+			return fmt.Errorf("aah/cache/%s: metrics %s", p.name, err)
+		}
+	}
+
+	gob.Register(entry{})
+	if p.Connected() {
+		p.logger.Infof("aah/cache/provider: %s connected successfully with %s", p.name, addr)
+	}
+
+	return nil
+}
+
+// Create method creates new Redis cache with given options.
+func (p *Provider) Create(cfg *cache.Config) (cache.Cache, error) {
+	ccfg := *cfg
+	r := &redisCache{
+		cfg:             &ccfg,
+		keyPrefix:       p.keyPrefix(cfg.Name),
+		p:               p,
+		cref:            p.cref,
+		logOps:          p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "log.operations"), false),
+		slowOpThreshold: parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cfg.Name, "slow_op_threshold"), ""), "0s"),
+	}
+	if err := p.checkKeyPrefix(r.keyPrefix); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: %v", cfg.Name, err)
+	}
+	var err error
+	if r.logger, err = p.newCacheLogger(cfg.Name); err != nil {
+		return nil, err
+	}
+	if mode, found := p.appCfg.String(p.cfgPrefix + "caches." + cfg.Name + ".eviction_mode"); found {
+		if r.cfg.EvictionMode, err = parseEvictionMode(mode); err != nil {
+			return nil, fmt.Errorf("aah/cache/%s: %v", cfg.Name, err)
+		}
+	}
+	if opts, err := p.cacheClientOptions(cfg.Name, p.clientOpts, p.connSettings()); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: %v", cfg.Name, err)
+	} else if opts != nil {
+		r.cref = newClientRef(p.newRedisClient(opts))
+	}
+	r.db = p.db
+	if db, found := p.appCfg.Int(p.cfgPrefix + "caches." + cfg.Name + ".db"); found {
+		r.db = db
+	}
+	// allow_flushdb is read only from the cache config, so that one provider
+	// level config can't enable FLUSHDB for every cache
+	r.allowFlushDB = p.appCfg.BoolDefault(p.cfgPrefix+"caches."+cfg.Name+".allow_flushdb", false)
+	if r.cref == p.cref && p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "replica.read"), true) {
+		r.replicas = p.replicas
+	}
+	if r.slideThreshold = p.appCfg.IntDefault(p.cacheCfgKey(cfg.Name, "slide.refresh_threshold"), 100); r.slideThreshold <= 0 || r.slideThreshold > 100 {
+		return nil, fmt.Errorf("aah/cache/%s: slide.refresh_threshold must be between 1 and 100", cfg.Name)
+	}
+	r.slideInterval = parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cfg.Name, "slide.refresh_interval"), "0s"), "0s")
+	if r.ttl, err = p.newTTLPolicy(cfg.Name); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: %v", cfg.Name, err)
+	}
+	if r.kh, err = p.newKeyHasher(cfg.Name); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: %v", cfg.Name, err)
+	}
+	if size, found := p.appCfg.String(p.cacheCfgKey(cfg.Name, "max_value_size")); found {
+		if r.maxValueSize, err = parseSize(size); err != nil {
+			return nil, fmt.Errorf("aah/cache/%s: max_value_size %v", cfg.Name, err)
+		}
+		switch r.oversizePolicy = p.appCfg.StringDefault(p.cacheCfgKey(cfg.Name, "oversize_policy"), oversizeReject); r.oversizePolicy {
+		case oversizeReject, oversizeWarn, oversizeCompress:
+		default:
+			return nil, fmt.Errorf("aah/cache/%s: unsupported oversize_policy '%s'", cfg.Name, r.oversizePolicy)
+		}
+	}
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "encryption.enable"), false) {
+		if r.aead, err = p.newAEAD(cfg.Name); err != nil {
+			return nil, fmt.Errorf("aah/cache/%s: encryption %v", cfg.Name, err)
+		}
+	}
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "local.enable"), false) {
+		r.local = newLocalCache(
+			p.appCfg.IntDefault(p.cacheCfgKey(cfg.Name, "local.max_entries"), 10000),
+			parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cfg.Name, "local.ttl"), "1m"), "1m"),
+		)
+	}
+	if p.cb != nil && p.appCfg.StringDefault(p.cfgPrefix+"circuit_breaker.fallback", "none") == "memory" {
+		r.fallback = newLocalCache(
+			p.appCfg.IntDefault(p.cfgPrefix+"circuit_breaker.fallback_max_entries", 1000),
+			parseDuration(p.appCfg.StringDefault(p.cfgPrefix+"circuit_breaker.fallback_ttl", "1m"), "1m"),
+		)
+	}
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "stampede_protection"), false) {
+		r.sp = &stampede{
+			lockTTL: parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cfg.Name, "stampede_lock_ttl"), ""), "0s"),
+		}
+	}
+	r.meta = p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "metadata.enable"), false)
+	r.failOpen = p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "fail_open"), false)
+	if r.formatVersion = p.appCfg.IntDefault(p.cacheCfgKey(cfg.Name, "format.version"), 0); r.formatVersion < 0 {
+		return nil, fmt.Errorf("aah/cache/%s: format.version must not be negative", cfg.Name)
+	}
+	if r.layout, err = p.newLayout(r); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: %v", cfg.Name, err)
+	}
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "coalesce_gets"), false) {
+		r.co = &coalescer{}
+	}
+	if beta, err := strconv.ParseFloat(p.appCfg.StringDefault(p.cacheCfgKey(cfg.Name, "xfetch.beta"), "0"), 64); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: xfetch.beta %v", cfg.Name, err)
+	} else if beta > 0 {
+		r.xf = &xfetch{beta: beta}
+	}
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "invalidation.enable"), r.local != nil) {
+		channel := p.appCfg.StringDefault(p.cacheCfgKey(cfg.Name, "invalidation.channel"), "aah:cache:invalidate:"+r.keyPrefix)
+		var err error
+		if r.inv, err = newInvalidator(r, channel); err != nil {
+			return nil, fmt.Errorf("aah/cache/%s: invalidation %v", cfg.Name, err)
+		}
+	}
+
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "event_log.enable"), false) {
+		r.events = p.newEventLog(r)
+	}
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "audit.enable"), false) {
+		var err error
+		if r.at, err = p.newAuditTrail(cfg.Name); err != nil {
+			return nil, fmt.Errorf("aah/cache/%s: %v", cfg.Name, err)
+		}
+	}
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "refresh_ahead.enable"), false) {
+		var err error
+		if r.ra, err = p.newRefresher(r); err != nil {
+			return nil, fmt.Errorf("aah/cache/%s: refresh_ahead %v", cfg.Name, err)
+		}
+	}
+
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "generation.enable"), false) {
+		r.gen = p.newGeneration(r)
+	}
+	r.negativeTTL = parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cfg.Name, "negative_ttl"), ""), "0s")
+	r.writeThroughAsync = p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "write_through.async"), false)
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "write_behind.enable"), false) {
+		var err error
+		if r.wb, err = p.newWriteBehind(r); err != nil {
+			return nil, fmt.Errorf("aah/cache/%s: write_behind %v", cfg.Name, err)
+		}
+	}
+	if p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "keyspace_events.enable"), false) {
+		var err error
+		configure := p.appCfg.BoolDefault(p.cacheCfgKey(cfg.Name, "keyspace_events.configure"), false)
+		if r.el, err = newEvictionListener(r, r.db, configure); err != nil {
+			return nil, fmt.Errorf("aah/cache/%s: keyspace events %v", cfg.Name, err)
+		}
+	}
+
+	p.mu.Lock()
+	p.caches = append(p.caches, r)
+	p.mu.Unlock()
+	r.warmup()
+	return r, nil
+}
+
+// Close method gracefully shuts down the provider, it stops the background
+// goroutines, closes the invalidation subscriptions, unregisters the pool
+// metrics and closes the Redis client. Client supplied via
+// `ProviderWithClient` is not closed, its owner is responsible for it.
+//
+// Call it from the aah application `OnPostShutdown` event.
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	close(p.closing)
+
+	var errs []string
+	for _, r := range p.caches {
+		if r.wb != nil {
+			r.wb.close()
+		}
+		if r.ra != nil {
+			r.ra.close()
+		}
+		if r.inv != nil {
+			if err := r.inv.close(); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+		if r.el != nil {
+			if err := r.el.close(); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+		if r.cref != p.cref {
+			if err := r.client().Close(); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+	if p.replicas != nil {
+		errs = append(errs, p.replicas.close()...)
+	}
+	if p.pools != nil {
+		errs = append(errs, p.pools.close()...)
+	}
+	p.metrics.unregister()
+	if p.ownsClient {
+		if err := p.client().Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if p.embedded != nil {
+		p.embedded.Close()
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("aah/cache/%s: %s", p.name, strings.Join(errs, ", "))
+	}
+	p.logger.Infof("aah/cache/provider: %s closed successfully", p.name)
+	return nil
+}
+
+// Client method returns underlying redis client. So that aah user could perform
+// cache provider specific features. It returns nil if the provider uses the
+// supplied client other than `*redis.Client`, use `UniversalClient` instead.
+func (p *Provider) Client() *redis.Client {
+	c, _ := p.client().(*redis.Client)
+	return c
+}
+
+// UniversalClient method returns underlying redis client. It returns nil if
+// the provider uses the supplied Commander other than go-redis client.
+func (p *Provider) UniversalClient() redis.UniversalClient {
+	c, _ := p.client().(redis.UniversalClient)
+	return c
+}
+
+// newClient method creates the Redis client for config `mode`, values are
+// `standalone`, `ring`, `cluster` and `embedded`. It returns the client
+// options for the single node modes, the connection settings and the address
+// description for logging.
+func (p *Provider) newClient() (redis.UniversalClient, *redis.Options, connSettings, string, error) {
+	opts, cs, err := p.newClientOptions()
+	if err != nil {
+		return nil, nil, cs, "", err
+	}
+	switch mode := p.appCfg.StringDefault(p.cfgPrefix+"mode", "standalone"); mode {
+	case "standalone":
+		return p.newRedisClient(opts), opts, cs, opts.Addr, nil
+	case "ring":
+		ring, err := p.newRing(opts)
+		if err != nil {
+			return nil, nil, cs, "", err
+		}
+		addrs, _ := p.appCfg.StringList(p.cfgPrefix + "addresses")
+		return ring, nil, cs, "ring " + strings.Join(addrs, ", "), nil
+	case "cluster":
+		cluster, err := p.newCluster(opts)
+		if err != nil {
+			return nil, nil, cs, "", err
+		}
+		return cluster, nil, cs, "cluster " + opts.Addr, nil
+	case "embedded":
+		if err := p.newEmbedded(opts); err != nil {
+			return nil, nil, cs, "", fmt.Errorf("embedded %s", err)
+		}
+		return p.newRedisClient(opts), opts, cs, "embedded " + opts.Addr, nil
+	default:
+		return nil, nil, cs, "", fmt.Errorf("unsupported mode '%s'", mode)
+	}
+}
+
+// connSettings struct holds the connection settings resolved from config. It's
+// assigned to the provider only after the client is created, on `Reload` after
+// the new client is verified.
+type connSettings struct {
+	address    string
+	db         int
+	username   string
+	password   string
+	clientName string
+}
+
+// auth method returns true if the connection is authenticated on connect.
+func (cs connSettings) auth(p *Provider) bool {
+	return len(cs.username) > 0 || p.credentials != nil
+}
+
+func (p *Provider) connSettings() connSettings {
+	return connSettings{
+		address:    p.address,
+		db:         p.db,
+		username:   p.username,
+		password:   p.password,
+		clientName: p.clientName,
+	}
+}
+
+func (p *Provider) setConnSettings(cs connSettings) {
+	p.address, p.db = cs.address, cs.db
+	p.username, p.password, p.clientName = cs.username, cs.password, cs.clientName
+}
+
+// newClientOptions method creates the Redis client options from config. The
+// connection URL `url` (e.g. `rediss://:password@host:6380/2`) takes precedence
+// over the `network`, `address`, `password` and `db` config. Redis 6 ACL user
+// is configured via `username` config. TLS is enabled via `tls.enable`.
+// Address `srv://<name>` is resolved using DNS SRV records. Provider fields are
+// not modified, resolved connection settings are returned.
+func (p *Provider) newClientOptions() (*redis.Options, connSettings, error) {
+	var cs connSettings
+	cfgPrefix := p.cfgPrefix
+	opts := &redis.Options{
 		Network:            p.appCfg.StringDefault(cfgPrefix+"network", "tcp"),
 		Addr:               p.appCfg.StringDefault(cfgPrefix+"address", ":6379"),
 		Password:           p.appCfg.StringDefault(cfgPrefix+"password", ""),
 		DB:                 p.appCfg.IntDefault(cfgPrefix+"db", 0),
-		PoolSize:           p.appCfg.IntDefault(cfgPrefix+"pool_size", 10*runtime.NumCPU()),
+		PoolSize:           p.appCfg.IntDefault(cfgPrefix+"pool.write.size", p.appCfg.IntDefault(cfgPrefix+"pool_size", 10*runtime.NumCPU())),
 		DialTimeout:        parseDuration(p.appCfg.StringDefault(cfgPrefix+"timeout.connect", "5s"), "5s"),
 		ReadTimeout:        parseDuration(p.appCfg.StringDefault(cfgPrefix+"timeout.read", "3s"), "3s"),
 		WriteTimeout:       parseDuration(p.appCfg.StringDefault(cfgPrefix+"timeout.write", "3s"), "3s"),
@@ -60,33 +465,377 @@ func (p *Provider) Init(providerName string, appCfg *config.Config, logger log.L
 		IdleCheckFrequency: parseDuration(p.appCfg.StringDefault(cfgPrefix+"idle_check_interval", "1m"), "1m"),
 		MinRetryBackoff:    parseDuration(p.appCfg.StringDefault(cfgPrefix+"retry_backoff.min", "8ms"), "8ms"),
 		MaxRetryBackoff:    parseDuration(p.appCfg.StringDefault(cfgPrefix+"retry_backoff.max", "512ms"), "512ms"),
+		MaxRetries:         p.appCfg.IntDefault(cfgPrefix+"max_retries", 0),
+		MinIdleConns:       p.appCfg.IntDefault(cfgPrefix+"pool.min_idle", 0),
+		MaxConnAge:         parseDuration(p.appCfg.StringDefault(cfgPrefix+"pool.max_conn_age", "0s"), "0s"),
+		TLSConfig:          p.newTLSConfig(),
 	}
 
-	p.client = redis.NewClient(p.clientOpts)
-	if _, err := p.client.Ping().Result(); err != nil {
-		return fmt.Errorf("aah/cache/%s: %s", p.name, err)
+	if u := p.appCfg.StringDefault(cfgPrefix+"url", ""); len(u) > 0 {
+		uopts, err := redis.ParseURL(u)
+		if err != nil {
+			return nil, cs, err
+		}
+		opts.Network = uopts.Network
+		opts.Addr = uopts.Addr
+		opts.Password = uopts.Password
+		opts.DB = uopts.DB
+		opts.TLSConfig = uopts.TLSConfig
+	}
+	if cs.address = opts.Addr; p.discoverable(opts.Addr) {
+		addrs, err := p.resolveAddresses(opts.Addr)
+		if err != nil {
+			return nil, cs, err
+		}
+		opts.Addr = addrs[0]
 	}
 
-	gob.Register(entry{})
-	p.logger.Infof("aah/cache/provider: %s connected successfully with %s", p.name, p.clientOpts.Addr)
+	cs.db = opts.DB
+
+	// Redis 6 ACL username or credentials provider is authenticated on connect,
+	// then the connection name is set and the registered callbacks are invoked
+	cs.username = p.appCfg.StringDefault(cfgPrefix+"username", "")
+	cs.clientName = p.newClientName()
+	if cs.auth(p) {
+		cs.password = opts.Password
+		opts.Password, opts.DB = "", 0
+	}
+	opts.OnConnect = p.newOnConnect(cs, cs.db)
+
+	return opts, cs, nil
+}
+
+// cacheClientOptions method returns the Redis client options for the cache if
+// it overrides the provider connection settings otherwise nil. Options are
+// derived from given provider client options and connection settings.
+func (p *Provider) cacheClientOptions(cacheName string, base *redis.Options, cs connSettings) (*redis.Options, error) {
+	cfgPrefix := p.cfgPrefix + "caches." + cacheName + "."
+	opts, overridden := redis.Options{}, false
+	if base != nil {
+		opts = *base
+	}
+
+	if db, found := p.appCfg.Int(cfgPrefix + "db"); found && db != cs.db {
+		if cs.auth(p) {
+			opts.OnConnect = p.newOnConnect(cs, db)
+		} else {
+			opts.DB = db
+		}
+		overridden = true
+	}
+	for key, n := range map[string]*int{
+		"pool_size":     &opts.PoolSize,
+		"pool.min_idle": &opts.MinIdleConns,
+		"max_retries":   &opts.MaxRetries,
+	} {
+		if v, found := p.appCfg.Int(cfgPrefix + key); found {
+			*n, overridden = v, true
+		}
+	}
+	for key, d := range map[string]*time.Duration{
+		"timeout.connect":   &opts.DialTimeout,
+		"timeout.read":      &opts.ReadTimeout,
+		"timeout.write":     &opts.WriteTimeout,
+		"timeout.pool":      &opts.PoolTimeout,
+		"timeout.idle":      &opts.IdleTimeout,
+		"pool.max_conn_age": &opts.MaxConnAge,
+	} {
+		if v, found := p.appCfg.String(cfgPrefix + key); found {
+			*d, overridden = parseDuration(v, d.String()), true
+		}
+	}
 
+	if !overridden {
+		return nil, nil
+	}
+	if base == nil {
+		return nil, errors.New("connection settings override is supported only in standalone mode")
+	}
+	return &opts, nil
+}
+
+// keyPrefix method returns the cache key prefix for given cache. Prefix is
+// templated via config `key_prefix`, e.g. `{app}:{env}:{cache}:`, placeholders
+// are `{app}` application name, `{env}` active environment profile, `{provider}`
+// provider name, `{namespace}` config `namespace` and `{cache}` cache name.
+// Default is `<namespace><cache name>-`.
+func (p *Provider) keyPrefix(cacheName string) string {
+	namespace := p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "namespace"), "")
+	tmpl, found := p.appCfg.String(p.cacheCfgKey(cacheName, "key_prefix"))
+	if !found {
+		return namespace + cacheName + "-"
+	}
+	return strings.NewReplacer(
+		"{app}", p.appCfg.StringDefault("name", ""),
+		"{env}", p.appCfg.StringDefault("env.active", ""),
+		"{provider}", p.name,
+		"{namespace}", namespace,
+		"{cache}", cacheName,
+	).Replace(tmpl)
+}
+
+// checkKeyPrefix method returns an error if given key prefix is empty or
+// overlaps the key prefix of the created caches, i.e. one is the prefix of the
+// other, including the same prefix rendered for several caches. Flush,
+// DeleteByPattern, Size and Keys scan the keys by prefix, so the caches with
+// overlapping prefixes would delete and count each other's entries.
+func (p *Provider) checkKeyPrefix(prefix string) error {
+	if len(prefix) == 0 {
+		return errors.New("key prefix must not be empty")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, c := range p.caches {
+		if strings.HasPrefix(c.keyPrefix, prefix) || strings.HasPrefix(prefix, c.keyPrefix) {
+			return fmt.Errorf("key prefix '%s' overlaps the key prefix '%s' of cache '%s'", prefix, c.keyPrefix, c.Name())
+		}
+	}
 	return nil
 }
 
-// Create method creates new Redis cache with given options.
-func (p *Provider) Create(cfg *cache.Config) (cache.Cache, error) {
-	p.cfg = cfg
-	r := &redisCache{
-		keyPrefix: p.cfg.Name + "-",
-		p:         p,
+// cacheCfgKey method returns the config key for given cache. Cache level config
+// `cache.<provider>.caches.<cache name>.<key>` takes precedence over the
+// provider level config `cache.<provider>.<key>`.
+func (p *Provider) cacheCfgKey(cacheName, key string) string {
+	if ck := p.cfgPrefix + "caches." + cacheName + "." + key; p.appCfg.IsExists(ck) {
+		return ck
 	}
-	return r, nil
+	return p.cfgPrefix + key
 }
 
-// Client method returns underlying redis client. So that aah user could perform
-// cache provider specific features.
-func (p *Provider) Client() *redis.Client {
-	return p.client
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Cache interface
+//______________________________________________________________________________
+
+// Cache interface extends `cache.Cache` with Redis cache provider specific
+// features. Cache instance created by Redis provider could be type asserted
+// to access these features.
+//
+//	c := aah.App().CacheManager().Cache("mycache").(redis.Cache)
+type Cache interface {
+	cache.Cache
+
+	// GetWithTTL method returns the cached entry and its remaining time to live
+	// for given key if it exists otherwise nil and zero duration.
+	GetWithTTL(k string) (interface{}, time.Duration)
+
+	// GetInto method decodes the cached entry for given key into the value
+	// pointed to by dest. It returns `ErrCacheMiss` if the entry does not exists.
+	GetInto(k string, dest interface{}) error
+
+	// GetE method returns the cached entry for given key. It returns
+	// `ErrCacheMiss` if the entry does not exists, otherwise the error on
+	// Redis transport or decode failure.
+	GetE(k string) (interface{}, error)
+
+	// PutNotFound method caches the "not found" result for given key, Get
+	// returns `NotFound` and GetE returns `ErrNotFound` for the key.
+	PutNotFound(k string) error
+
+	// GetIfExists method returns the cached entry for given key and true if it
+	// exists, otherwise nil and false.
+	GetIfExists(k string) (interface{}, bool)
+
+	// GetOrPutE method returns the cached entry if it exists otherwise it puts
+	// the given value. It reports true if the value is stored by this call.
+	GetOrPutE(k string, v interface{}, d time.Duration) (interface{}, bool, error)
+
+	// PutIfAbsent method adds the cache entry only if it does not exists. It
+	// returns true if the cache entry is added.
+	PutIfAbsent(k string, v interface{}, d time.Duration) (bool, error)
+
+	// PutIfPresent method replaces the cache entry only if it exists. It
+	// returns true if the cache entry is replaced.
+	PutIfPresent(k string, v interface{}, d time.Duration) (bool, error)
+
+	// GetWithVersion method returns the cached entry and its version token for
+	// given key. It returns `ErrCacheMiss` if the entry does not exists.
+	GetWithVersion(k string) (interface{}, string, error)
+
+	// PutIfVersion method puts the cache entry only if the stored entry version
+	// matches the given version. Empty version means the entry must not exists.
+	PutIfVersion(k string, v interface{}, version string, d time.Duration) (bool, error)
+
+	// PutFields method puts the struct value as Redis hash with field per
+	// exported struct field.
+	PutFields(k string, v interface{}, d time.Duration) error
+
+	// GetFields method decodes the cached hash entry stored by `PutFields`
+	// into the struct pointed to by dest.
+	GetFields(k string, dest interface{}) error
+
+	// GetField method returns the value of the hash field from the cached
+	// entry stored by `PutFields`.
+	GetField(k, field string) (interface{}, error)
+
+	// SetField method updates the value of the hash field in the cached entry
+	// stored by `PutFields`.
+	SetField(k, field string, v interface{}) error
+
+	// Swap method atomically replaces the cache entry and returns the previous
+	// value. Previous value is nil if the entry does not exists.
+	Swap(k string, v interface{}, d time.Duration) (interface{}, error)
+
+	// Touch method resets the cache entry expiration to its original duration
+	// without transferring the value.
+	Touch(k string) error
+
+	// Expire method sets the new expiration of the cache entry without
+	// transferring the value, zero or negative duration removes the expiration.
+	Expire(k string, d time.Duration) error
+
+	// TTL method returns the remaining time to live of the cache entry.
+	TTL(k string) (time.Duration, error)
+
+	// Stats method returns the operation statistics of the cache, including
+	// the latency percentiles of Get, Put and Delete.
+	Stats() Stats
+
+	// OnInvalidate method registers the callback, it's called when the cache
+	// entry is changed or deleted by other provider instance. Empty key means
+	// all the cache entries are invalidated.
+	OnInvalidate(fn func(key string))
+
+	// OnEvicted method registers the callback, it's called when the cache entry
+	// is expired, evicted or deleted on the Redis server.
+	OnEvicted(fn func(key string))
+
+	// Pipeline method queues the cache operations issued within fn and sends
+	// them to Redis in one round trip.
+	Pipeline(fn func(p CachePipeliner) error) error
+
+	// Tx method queues the cache operations issued within fn and executes them
+	// atomically using Redis transaction.
+	Tx(fn func(p CachePipeliner) error) error
+
+	// EvalScript method executes the script registered via
+	// `Provider.RegisterScript` with given cache keys and args.
+	EvalScript(name string, keys []string, args ...interface{}) (interface{}, error)
+
+	// Warmup method preloads the given entries into cache store using
+	// pipelined writes.
+	Warmup(ctx context.Context, entries map[string]WarmEntry) error
+
+	// InvalidateAll method invalidates all the cache entries by bumping the
+	// cache generation in O(1), it falls back to Flush if the generation is not
+	// enabled.
+	InvalidateAll() error
+
+	// Append method appends to the string value of the cache entry and
+	// returns the value length.
+	Append(k, s string) (int64, error)
+
+	// SetRange method overwrites the string value of the cache entry at the
+	// offset and returns the value length.
+	SetRange(k string, offset int64, s string) (int64, error)
+
+	// ExistsMulti method reports whether the cache entry exists for each of
+	// given keys in single Redis round trip.
+	ExistsMulti(keys ...string) (map[string]bool, error)
+
+	// EventConsumer method returns the consumer of the cache event log for
+	// given consumer group and consumer name.
+	EventConsumer(group, consumer string) (*EventConsumer, error)
+
+	// ReplayEvents method returns up to count events of the cache event log
+	// starting from the event ID.
+	ReplayEvents(fromID string, count int64) ([]Event, error)
+
+	// PFAdd method adds the elements to the HyperLogLog for given key.
+	PFAdd(k string, elements ...interface{}) (bool, error)
+
+	// PFCount method returns the approximated number of unique elements of
+	// the HyperLogLog for given keys.
+	PFCount(keys ...string) (int64, error)
+
+	// SetBit method sets or clears the bit at offset in the bitmap for given
+	// key and returns its previous value.
+	SetBit(k string, offset int64, value bool) (bool, error)
+
+	// GetBit method returns the bit at offset in the bitmap for given key.
+	GetBit(k string, offset int64) (bool, error)
+
+	// BitCount method returns the number of set bits in the bitmap for given
+	// key.
+	BitCount(k string) (int64, error)
+
+	// RefreshAhead method registers the keys for refresh-ahead.
+	RefreshAhead(keys ...string) error
+
+	// RefreshAheadPattern method registers the glob patterns for
+	// refresh-ahead.
+	RefreshAheadPattern(patterns ...string) error
+
+	// StopRefreshAhead method unregisters the keys from refresh-ahead.
+	StopRefreshAhead(keys ...string)
+
+	// DeleteByPattern method deletes the cache entries matching the
+	// glob-style pattern and returns the number of deleted entries.
+	DeleteByPattern(glob string) (int64, error)
+
+	// FlushDryRun method returns the number of keys Flush would delete without
+	// deleting them.
+	FlushDryRun() (int64, error)
+
+	// DeleteByPatternDryRun method returns the number of cache entries
+	// DeleteByPattern would delete without deleting them.
+	DeleteByPatternDryRun(glob string) (int64, error)
+
+	// Take method atomically returns and deletes the cache entry.
+	Take(k string) (interface{}, error)
+
+	// GetAndExtend method returns the cache entry and sets its expiration to
+	// given duration in a single round trip.
+	GetAndExtend(k string, d time.Duration) (interface{}, error)
+
+	// Inspect method returns the metadata of the cache entry, i.e. creation
+	// time, hit count and remaining time to live.
+	Inspect(k string) (EntryInfo, error)
+
+	// Keys method returns a page of the cache keys matching the pattern
+	// starting from the cursor and the cursor of the next page.
+	Keys(cursor uint64, match string, count int64) ([]string, uint64, error)
+
+	// Size method returns the number of cache entries.
+	Size() (int64, error)
+
+	// MemoryUsage method returns the number of bytes used by the cache entry
+	// in Redis.
+	MemoryUsage(k string) (int64, error)
+
+	// TotalMemoryUsage method returns the number of bytes used by all the
+	// cache entries in Redis.
+	TotalMemoryUsage() (int64, error)
+
+	// Scoped method returns the view of the cache scoped to given tenant, its
+	// Flush deletes only the tenant entries.
+	Scoped(tenantID string) cache.Cache
+
+	// WithContext method returns the view of the cache which attributes the
+	// mutations to the actor carried by ctx in the audit trail.
+	WithContext(ctx context.Context) cache.Cache
+
+	// Export method streams all the cache entries into w in portable format.
+	Export(w io.Writer) error
+
+	// Import method reads the cache entries exported by `Export` from r and
+	// stores them into cache store.
+	Import(r io.Reader) error
+
+	// RegisterCodec method registers the codec for the Go type of v, values
+	// of the type are stored using the codec instead of gob.
+	RegisterCodec(name string, v interface{}, c Codec) error
+
+	// AddMigration method registers the migration of the cache entries from
+	// the format version to the next one.
+	AddMigration(fromVersion int, fn Migration)
+
+	// SetLoader method sets the read-through loader of the cache.
+	SetLoader(fn Loader)
+
+	// SetWriteThrough method sets the write-through callback of the cache, it's
+	// invoked on Put.
+	SetWriteThrough(fn WriteThrough)
 }
 
 //‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
@@ -94,115 +843,610 @@ func (p *Provider) Client() *redis.Client {
 //______________________________________________________________________________
 
 type redisCache struct {
-	keyPrefix string
-	p         *Provider
+	stats             cacheStats
+	cfg               *cache.Config
+	keyPrefix         string
+	p                 *Provider
+	cref              *clientRef
+	logger            *cacheLogger
+	logOps            bool
+	negativeTTL       time.Duration
+	slowOpThreshold   time.Duration
+	db                int
+	allowFlushDB      bool
+	slideThreshold    int
+	slideInterval     time.Duration
+	ttl               ttlPolicy
+	local             *localCache
+	inv               *invalidator
+	el                *evictionListener
+	wb                *writeBehind
+	aead              cipher.AEAD
+	kh                *keyHasher
+	replicas          *replicas
+	gen               *generation
+	maxValueSize      int
+	oversizePolicy    string
+	sp                *stampede
+	co                *coalescer
+	meta              bool
+	loader            Loader
+	writeThrough      WriteThrough
+	writeThroughAsync bool
+	xf                *xfetch
+	fallback          *localCache
+	failOpen          bool
+	ra                *refresher
+	formatVersion     int
+	layout            string
+	codecs            *codecs
+	events            *eventLog
+	at                *auditTrail
+	migrations        map[int]Migration
 }
 
 var _ cache.Cache = (*redisCache)(nil)
+var _ Cache = (*redisCache)(nil)
 
 // Name method returns the cache store name.
 func (r *redisCache) Name() string {
-	return r.p.cfg.Name
+	return r.cfg.Name
 }
 
 // Get method returns the cached entry for given key if it exists otherwise nil.
-// Method uses `gob.Decoder` to unmarshal cache value from bytes.
+// Method uses `gob.Decoder` to unmarshal cache value from bytes, string,
+// []byte, bool and number values are stored in raw format without gob.
+//
+// If the cache has the loader, on cache miss the value is loaded using the
+// loader and stored into cache store.
 func (r *redisCache) Get(k string) interface{} {
-	k = r.keyPrefix + k
-	v, err := r.p.client.Get(k).Bytes()
+	return r.getOrLoad(context.Background(), k)
+}
+
+func (r *redisCache) getOrLoad(ctx context.Context, k string) interface{} {
+	if v := r.get(ctx, k); v != nil {
+		return v
+	}
+	if r.loader != nil {
+		return r.load(ctx, k)
+	}
+	return nil
+}
+
+// GetE method returns the cached entry for given key. It returns `ErrCacheMiss`
+// if the entry does not exists, otherwise the error on Redis transport or
+// decode failure, so that the callers could distinguish the cache miss from
+// Redis being unavailable.
+//
+// If the cache has the loader, on cache miss the value is loaded using the
+// loader and stored into cache store. It returns `ErrNotFound` if the key is
+// negatively cached.
+func (r *redisCache) GetE(k string) (interface{}, error) {
+	v, err := r.getE(context.Background(), k)
+	if err == ErrCacheMiss && r.loader != nil {
+		if v = r.load(context.Background(), k); v != nil {
+			err = nil
+		}
+	}
+	if v == NotFound {
+		return nil, ErrNotFound
+	}
+	return v, err
+}
+
+// GetIfExists method returns the cached entry for given key and true if it
+// exists, otherwise nil and false. It's done in single Redis round trip
+// instead of `Exists` followed by `Get`. Loader is not invoked on cache miss.
+//
+//	if v, found := c.GetIfExists("key1"); found {
+//		// use v
+//	}
+func (r *redisCache) GetIfExists(k string) (interface{}, bool) {
+	v, err := r.getE(context.Background(), k)
+	return v, err == nil
+}
+
+func (r *redisCache) get(ctx context.Context, k string) interface{} {
+	v, _ := r.getE(ctx, k)
+	return v
+}
+
+func (r *redisCache) getE(ctx context.Context, k string) (interface{}, error) {
+	start := r.beginCtx(ctx, opGet, k)
+	if r.local != nil {
+		if v, found := r.local.Get(k); found {
+			r.metaHit(k)
+			r.stats.hit()
+			r.observe(opGet, k, resultHit, start)
+			return v, nil
+		}
+	}
+	if r.circuitOpen() {
+		var v interface{}
+		if r.fallback != nil {
+			v, _ = r.fallback.Get(k)
+		}
+		if v == nil {
+			r.stats.miss()
+			r.observe(opGet, k, resultMiss, start)
+			if r.failOpen {
+				return nil, ErrCacheMiss
+			}
+			return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+		}
+		r.stats.hit()
+		r.observe(opGet, k, resultHit, start)
+		return v, nil
+	}
+	v, err := r.fetch(k)
+	r.p.done(notacacheMiss(err))
 	if err != nil {
-		if notacacheMiss(err) != nil {
-			r.p.logger.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k[len(r.keyPrefix):], err)
+		r.stats.miss()
+		if err = notacacheMiss(err); err == nil {
+			r.observe(opGet, k, resultMiss, start)
+			return nil, ErrCacheMiss
 		}
-		return nil
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		if r.failOpen {
+			r.logFor(opGet, k).warnf("aah/cache/%s: key(%s) treated as miss: %v", r.Name(), k, err)
+			return nil, ErrCacheMiss
+		}
+		r.logFor(opGet, k).errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
 	}
 
 	var e entry
-	err = gob.NewDecoder(bytes.NewBuffer(v)).Decode(&e)
+	err = r.decode(k, v, &e)
 	if err != nil {
-		r.p.logger.Errorf("aah/cache/%s: %v", r.Name(), err)
-		return nil
+		r.stats.error()
+		r.stats.miss()
+		r.observeError(opGet, k, err, start)
+		r.logFor(opGet, k).errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.stats.hit()
+	if r.cfg.EvictionMode == cache.EvictionModeSlide && v[0] != rawMarker && e.D > 0 {
+		err = r.client().Expire(r.key(k), e.D).Err()
+		r.p.done(err)
+		if err != nil {
+			r.stats.error()
+			r.logFor(opGet, k).errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+		}
+	}
+	if r.local != nil {
+		r.local.Put(k, e.V, e.D)
+	}
+	r.metaHit(k)
+	if r.ra != nil {
+		r.ra.touch(k)
 	}
-	if r.p.cfg.EvictionMode == cache.EvictionModeSlide {
-		if err = r.p.client.Expire(k, e.D).Err(); err != nil {
-			r.p.logger.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k[len(r.keyPrefix):], err)
+	r.observe(opGet, k, resultHit, start)
+
+	if r.xf != nil && r.loader != nil && e.xfetch(r.xf.beta) {
+		if nv := r.refresh(k); nv != nil {
+			return nv, nil
 		}
 	}
 
-	return e.V
+	return e.V, nil
 }
 
 // GetOrPut method returns the cached entry for the given key if it exists otherwise
 // it puts the new entry into cache store and returns the value.
+//
+// With stampede protection enabled, concurrent calls for the same key are
+// coalesced into one.
 func (r *redisCache) GetOrPut(k string, v interface{}, d time.Duration) (interface{}, error) {
-	ev := r.Get(k)
+	ev, _, err := r.GetOrPutE(k, v, d)
+	return ev, err
+}
+
+// GetOrPutE method is same as `GetOrPut`, in addition it reports true if the
+// given value is stored by this call and false if the existing cached entry is
+// returned.
+//
+//	v, stored, err := c.GetOrPutE("config", defaults, time.Hour)
+func (r *redisCache) GetOrPutE(k string, v interface{}, d time.Duration) (interface{}, bool, error) {
+	return r.getOrPut(context.Background(), k, v, d)
+}
+
+func (r *redisCache) getOrPut(ctx context.Context, k string, v interface{}, d time.Duration) (interface{}, bool, error) {
+	if r.sp != nil {
+		return r.getOrPutProtected(ctx, k, func() (interface{}, time.Duration, error) { return v, d, nil })
+	}
+	ev := r.get(ctx, k)
 	if ev == nil {
-		if err := r.Put(k, v, d); err != nil {
-			return nil, err
+		if err := r.putBy(ctx, k, v, d); err != nil {
+			return nil, false, err
 		}
-		return v, nil
+		return v, true, nil
 	}
-	return ev, nil
+	return ev, false, nil
 }
 
-// Put method adds the cache entry with specified expiration. Returns error
-// if cache entry exists. Method uses `gob.Encoder` to marshal cache value into bytes.
+// Put method adds the cache entry with specified expiration, existing cache
+// entry is overwritten. Write-through callback is invoked if it's set. Method
+// uses `gob.Encoder` to marshal cache value into bytes, type of the value is
+// registered with gob. String, []byte, bool and number values are stored in
+// raw format without gob.
 func (r *redisCache) Put(k string, v interface{}, d time.Duration) error {
-	e := entry{D: d, V: v}
+	return r.putBy(context.Background(), k, v, d)
+}
+
+// putBy method puts the entry attributed to the actor carried by ctx.
+func (r *redisCache) putBy(ctx context.Context, k string, v interface{}, d time.Duration) error {
+	registerType(reflect.TypeOf(v))
+	if err := r.writeThroughSync(k, v); err != nil {
+		return err
+	}
+	if err := r.put(k, &entry{D: d, V: v, actor: ActorFromContext(ctx), ctx: ctx}); err != nil {
+		return err
+	}
+	r.writeThroughBackground(k, v)
+	return nil
+}
+
+// PutIfAbsent method adds the cache entry with specified expiration only if
+// the cache entry does not exists using Redis `SET NX`. It returns true if the
+// cache entry is added.
+func (r *redisCache) PutIfAbsent(k string, v interface{}, d time.Duration) (bool, error) {
+	registerType(reflect.TypeOf(v))
+	return r.set(k, &entry{D: d, V: v}, setIfAbsent)
+}
+
+// PutIfPresent method replaces the cache entry with specified expiration only
+// if the cache entry exists using Redis `SET XX`. It returns true if the
+// cache entry is replaced.
+func (r *redisCache) PutIfPresent(k string, v interface{}, d time.Duration) (bool, error) {
+	registerType(reflect.TypeOf(v))
+	return r.set(k, &entry{D: d, V: v}, setIfPresent)
+}
+
+func (r *redisCache) put(k string, e *entry) error {
+	_, err := r.set(k, e, setAlways)
+	return err
+}
+
+func (r *redisCache) set(k string, e *entry, mode setMode) (bool, error) {
+	start := r.beginCtx(e.ctx, opPut, k)
+	e.D = r.expiration(k, e.D)
+	if e.D > 0 {
+		e.E = start.Add(e.D)
+	}
+	if r.circuitOpen() {
+		if r.fallback != nil {
+			if mode != setAlways {
+				if _, found := r.fallback.Get(k); found != (mode == setIfPresent) {
+					r.observe(opPut, k, resultOK, start)
+					return false, nil
+				}
+			}
+			r.fallback.Put(k, e.V, e.D)
+			r.stats.put()
+			r.observe(opPut, k, resultOK, start)
+			return true, nil
+		}
+		if r.failOpen {
+			r.dropPut(k, ErrCircuitOpen, start)
+			return false, nil
+		}
+		r.stats.error()
+		r.observeError(opPut, k, ErrCircuitOpen, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+
 	buf := acquireBuffer()
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(e); err != nil {
-		return fmt.Errorf("aah/cache/%s: %v", r.Name(), err)
+	if err := r.encode(k, buf, e); err != nil {
+		releaseBuffer(buf)
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return false, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	if r.wb != nil {
+		if mode == setAlways && r.wb.enqueue(k, buf.Bytes(), e.D) {
+			releaseBuffer(buf)
+			if r.local != nil {
+				r.local.Put(k, e.V, e.D)
+			}
+			r.stats.put()
+			r.observe(opPut, k, resultOK, start)
+			return true, nil
+		}
+		// queued entry of the key must not overwrite the synchronous write
+		r.wb.cancel(k)
 	}
 
-	cmd := r.p.client.Set(r.keyPrefix+k, buf.Bytes(), d)
+	var err error
+	stored := true
+	switch mode {
+	case setIfAbsent:
+		stored, err = r.client().SetNX(r.key(k), buf.Bytes(), e.D).Result()
+	case setIfPresent:
+		stored, err = r.client().SetXX(r.key(k), buf.Bytes(), e.D).Result()
+	default:
+		err = r.client().Set(r.key(k), buf.Bytes(), e.D).Err()
+	}
 	releaseBuffer(buf)
-	return cmd.Err()
+	r.p.done(err)
+	if err != nil {
+		if r.failOpen {
+			r.dropPut(k, err, start)
+			return false, nil
+		}
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return false, err
+	}
+	if !stored {
+		r.observe(opPut, k, resultOK, start)
+		return false, nil
+	}
+	if r.local != nil {
+		r.local.Put(k, e.V, e.D)
+	}
+	if r.inv != nil {
+		r.inv.publish(k)
+	}
+	r.emitBy(opPut, k, e.actor)
+	r.metaPut(k, e.D)
+	if r.ra != nil {
+		r.ra.touch(k)
+	}
+	r.stats.put()
+	r.observe(opPut, k, resultOK, start)
+	return true, nil
 }
 
 // Delete method deletes the cache entry from cache store.
 func (r *redisCache) Delete(k string) error {
-	if err := r.p.client.Del(r.keyPrefix + k).Err(); notacacheMiss(err) != nil {
+	return r.delete(context.Background(), k)
+}
+
+func (r *redisCache) delete(ctx context.Context, k string) error {
+	start := r.beginCtx(ctx, opDelete, k)
+	if r.local != nil {
+		r.local.Delete(k)
+	}
+	if r.circuitOpen() {
+		if r.fallback != nil {
+			r.fallback.Delete(k)
+		}
+		r.stats.error()
+		r.observeError(opDelete, k, ErrCircuitOpen, start)
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	if r.wb != nil {
+		r.wb.cancel(k)
+	}
+	err := notacacheMiss(r.client().Del(r.key(k)).Err())
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opDelete, k, err, start)
 		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
 	}
+	if r.inv != nil {
+		r.inv.publish(k)
+	}
+	r.emitBy(opDelete, k, ActorFromContext(ctx))
+	r.metaDelete(k)
+	r.stats.delete()
+	r.observe(opDelete, k, resultOK, start)
 	return nil
 }
 
 // Exists method checks given key exists in cache store and its not expried.
 func (r *redisCache) Exists(k string) bool {
-	result, err := r.p.client.Exists(r.keyPrefix + k).Result()
+	return r.exists(context.Background(), k)
+}
+
+func (r *redisCache) exists(ctx context.Context, k string) bool {
+	start := r.beginCtx(ctx, opExists, k)
+	if r.circuitOpen() {
+		var found bool
+		if r.fallback != nil {
+			_, found = r.fallback.Get(k)
+		}
+		r.observe(opExists, k, resultOK, start)
+		return found
+	}
+	var result int64
+	err := r.read(func(c Commander) error {
+		var err error
+		result, err = c.Exists(r.key(k)).Result()
+		return err
+	})
+	r.p.done(err)
 	if err != nil {
-		r.p.logger.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+		r.stats.error()
+		r.observeError(opExists, k, err, start)
+		r.logFor(opExists, k).errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
 		return false
 	}
+	r.observe(opExists, k, resultOK, start)
 	return result == 1
 }
 
-// Flush methods flushes(deletes) all the cache entries from cache.
+// GetWithTTL method returns the cached entry and its remaining time to live
+// for given key if it exists otherwise nil and zero duration.
+func (r *redisCache) GetWithTTL(k string) (interface{}, time.Duration) {
+	v := r.Get(k)
+	if v == nil {
+		return nil, 0
+	}
+	d, err := r.TTL(k)
+	if err != nil {
+		r.logFor(opTTL, k).errorf("%v", err)
+	}
+	return v, d
+}
+
+// TTL method returns the remaining time to live of the cache entry using Redis
+// TTL command. It returns zero duration if the cache entry does not exists and
+// -1 if the cache entry has no expiration.
+func (r *redisCache) TTL(k string) (time.Duration, error) {
+	start := r.begin(opTTL, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opTTL, k, ErrCircuitOpen, start)
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	d, err := r.client().TTL(r.key(k)).Result()
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opTTL, k, err, start)
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opTTL, k, resultOK, start)
+	return ttlValue(d), nil
+}
+
+// Flush methods flushes(deletes) all the cache entries from cache. If the cache
+// has its own Redis DB via config `caches.<cache name>.db` and
+// `caches.<cache name>.allow_flushdb = true`, the DB is flushed otherwise the
+// cache entries are deleted by key prefix, so that other caches sharing the
+// DB are not affected.
+// Use `FlushDryRun` to find out the number of keys it deletes.
 func (r *redisCache) Flush() error {
-	if err := r.p.client.FlushDB().Err(); err != nil {
+	return r.flush(context.Background())
+}
+
+func (r *redisCache) flush(ctx context.Context) error {
+	start := r.beginCtx(ctx, opFlush, "")
+	if r.local != nil {
+		r.local.Flush()
+	}
+	if r.circuitOpen() {
+		if r.fallback != nil {
+			r.fallback.Flush()
+		}
+		r.stats.error()
+		r.observeError(opFlush, "", ErrCircuitOpen, start)
+		return fmt.Errorf("aah/cache/%s: %v", r.Name(), ErrCircuitOpen)
+	}
+	if r.wb != nil {
+		r.wb.cancelAll()
+	}
+	var err error
+	if r.flushesDB() {
+		err = r.forEachShard(func(c Commander) error {
+			return c.FlushDB().Err()
+		})
+	} else {
+		if r.allowFlushDB {
+			r.logger.warnf("aah/cache/%s: allow_flushdb ignored, DB %d is the provider DB or shared with other cache", r.Name(), r.db)
+		}
+		err = r.deleteKeys(escapeGlob(r.keyPrefix) + "*")
+		if err == nil && r.meta {
+			err = r.deleteKeys(metaPrefix + escapeGlob(r.keyPrefix) + "*")
+		}
+	}
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.observeError(opFlush, "", err, start)
 		return fmt.Errorf("aah/cache/%s: %v", r.Name(), err)
 	}
+	if r.inv != nil {
+		r.inv.publish("")
+	}
+	r.emitBy(opFlush, "", ActorFromContext(ctx))
+	r.observe(opFlush, "", resultOK, start)
 	return nil
 }
 
+// Stats method returns the snapshot of operation statistics of the cache.
+func (r *redisCache) Stats() Stats {
+	return r.stats.snapshot()
+}
+
+// OnInvalidate method registers the callback, it's called when the cache
+// entry is changed or deleted by other provider instance. Empty key means all
+// the cache entries are invalidated. Invalidation is enabled via config
+// `invalidation.enable = true`, it's enabled by default for local cache layer.
+func (r *redisCache) OnInvalidate(fn func(key string)) {
+	if r.inv == nil {
+		r.logger.warnf("aah/cache/%s: invalidation is not enabled", r.Name())
+		return
+	}
+	r.inv.onInvalidate(fn)
+}
+
+// OnEvicted method registers the callback, it's called when the cache entry
+// is expired, evicted or deleted on the Redis server. It's driven by the
+// Redis keyspace notifications, enabled via config
+// `keyspace_events.enable = true`.
+func (r *redisCache) OnEvicted(fn func(key string)) {
+	if r.el == nil {
+		r.logger.warnf("aah/cache/%s: keyspace events is not enabled", r.Name())
+		return
+	}
+	r.el.onEvicted(fn)
+}
+
+// observe method records the cache operation outcome into the provider
+// instrumentation and the operation log.
+func (r *redisCache) observe(op, k, result string, start opStart) {
+	r.record(op, k, result, start, nil)
+}
+
+// observeError method records the failed cache operation with its error.
+func (r *redisCache) observeError(op, k string, err error, start opStart) {
+	r.record(op, k, resultError, start, err)
+}
+
+func (r *redisCache) record(op, k, result string, start opStart, err error) {
+	r.stats.latency.observe(op, time.Since(start.Time))
+	r.p.metrics.observe(r.Name(), op, result, start.Time)
+	endSpan(start.span, op, result)
+	r.logOp(op, k, result, start.Time)
+	r.afterOp(op, k, start.Time, err)
+}
+
 //‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
 // Helper methods
 //______________________________________________________________________________
 
+// entry struct is the cache entry stored in Redis. D is the expiration
+// duration, V is the value, E is the expiry time and C is the cost of
+// computing the value by the loader.
 type entry struct {
 	D time.Duration
 	V interface{}
+	E time.Time
+	C time.Duration
+
+	// actor of the mutation for the audit trail, it's not stored
+	actor string
+
+	// ctx of the caller, it parents the span of the operation, it's not stored
+	ctx context.Context
 }
 
+// setMode is the Redis SET command condition.
+type setMode uint8
+
+const (
+	setAlways setMode = iota
+	setIfAbsent
+	setIfPresent
+)
+
 var bufPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
 
+const maxPooledBufferSize = 64 << 10
+
 func acquireBuffer() *bytes.Buffer {
 	return bufPool.Get().(*bytes.Buffer)
 }
 
+// releaseBuffer method returns the buffer to the pool, buffer grown larger
+// than `maxPooledBufferSize` is discarded to not retain the memory of
+// occasional large values.
 func releaseBuffer(b *bytes.Buffer) {
-	if b != nil {
+	if b != nil && b.Cap() <= maxPooledBufferSize {
 		b.Reset()
 		bufPool.Put(b)
 	}
@@ -216,6 +1460,56 @@ func parseDuration(v, f string) time.Duration {
 	return d
 }
 
+// durationMillis method returns the duration in milliseconds for the Redis
+// `PX` argument, positive sub-millisecond duration is rounded up to 1.
+func durationMillis(d time.Duration) int64 {
+	ms := int64(d / time.Millisecond)
+	if d > 0 && ms == 0 {
+		ms = 1
+	}
+	return ms
+}
+
+// ttlValue method normalizes the Redis TTL reply. Redis replies -2 when the
+// key does not exists and -1 when the key has no expiration.
+func ttlValue(d time.Duration) time.Duration {
+	switch d {
+	case -2, -2 * time.Second:
+		return 0
+	case -1, -1 * time.Second:
+		return -1
+	}
+	return d
+}
+
+func parseEvictionMode(mode string) (cache.EvictionMode, error) {
+	switch strings.ToLower(mode) {
+	case "ttl":
+		return cache.EvictionModeTTL, nil
+	case "nottl", "no_ttl":
+		return cache.EvictionModeNoTTL, nil
+	case "slide":
+		return cache.EvictionModeSlide, nil
+	}
+	return cache.EvictionModeTTL, fmt.Errorf("unsupported eviction mode '%s'", mode)
+}
+
+// escapeGlob method escapes the Redis glob-style pattern special characters.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// ErrCacheMiss returned when the cache entry does not exists for given key.
+var ErrCacheMiss = errors.New("aah/cache: cache miss")
+
 func notacacheMiss(err error) error {
 	if err != nil && err.Error() == "redis: nil" {
 		return nil
@@ -15,6 +15,7 @@ import (
 	"aahframe.work/cache"
 	"aahframe.work/config"
 	"aahframe.work/log"
+	"github.com/go-redis/redis"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -180,6 +181,159 @@ func TestRedisSlideEvictionMode(t *testing.T) {
 	assert.Equal(t, "addgetcache", c.Name())
 }
 
+func TestRedisCacheTTL(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "ttlcache", ProviderName: "redis1"}).(Cache)
+
+	d, err := c.TTL("ttl-key1")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), d)
+
+	v, d := c.GetWithTTL("ttl-key1")
+	assert.Nil(t, v)
+	assert.Equal(t, time.Duration(0), d)
+
+	assert.Nil(t, c.Put("ttl-key1", "ttl value", 10*time.Second))
+	d, err = c.TTL("ttl-key1")
+	assert.Nil(t, err)
+	assert.True(t, d > 0 && d <= 10*time.Second)
+
+	v, d = c.GetWithTTL("ttl-key1")
+	assert.Equal(t, "ttl value", v)
+	assert.True(t, d > 0 && d <= 10*time.Second)
+
+	assert.Nil(t, c.Put("ttl-key2", "no expiry", 0))
+	d, err = c.TTL("ttl-key2")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(-1), d)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisMultipleCacheEvictionModes(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "slidecache", ProviderName: "redis1", EvictionMode: cache.EvictionModeSlide})
+	assert.Nil(t, err, "unable to create cache")
+	err = mgr.CreateCache(&cache.Config{Name: "ttlcache", ProviderName: "redis1", EvictionMode: cache.EvictionModeTTL})
+	assert.Nil(t, err, "unable to create cache")
+
+	sc := mgr.Cache("slidecache").(Cache)
+	tc := mgr.Cache("ttlcache").(Cache)
+
+	// creating the later cache does not change the earlier one
+	assert.Equal(t, "slidecache", sc.Name())
+	assert.Equal(t, "ttlcache", tc.Name())
+	assert.Equal(t, cache.EvictionModeSlide, sc.(*redisCache).cfg.EvictionMode)
+	assert.Equal(t, cache.EvictionModeTTL, tc.(*redisCache).cfg.EvictionMode)
+
+	assert.Nil(t, sc.Put("mode-key1", "value1", 3*time.Second))
+	assert.Nil(t, tc.Put("mode-key1", "value1", 3*time.Second))
+	time.Sleep(1100 * time.Millisecond)
+
+	// slide mode resets the expiration on access, ttl mode does not
+	assert.Equal(t, "value1", sc.Get("mode-key1"))
+	assert.Equal(t, "value1", tc.Get("mode-key1"))
+	d, err := sc.TTL("mode-key1")
+	assert.Nil(t, err)
+	assert.True(t, d > 2*time.Second)
+	d, err = tc.TTL("mode-key1")
+	assert.Nil(t, err)
+	assert.True(t, d <= 2*time.Second)
+
+	assert.Nil(t, sc.Flush())
+	assert.Nil(t, tc.Flush())
+}
+
+func TestRedisGetE(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "getecache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	v, err := c.GetE("key1")
+	assert.Nil(t, err)
+	assert.Equal(t, "value1", v)
+
+	v, err = c.GetE("notexists")
+	assert.Equal(t, ErrCacheMiss, err)
+	assert.Nil(t, v)
+	assert.Nil(t, c.Flush())
+
+	// Redis is down
+	c = createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6390"
+			timeout {
+				connect = "50ms"
+			}
+			connect {
+				lazy = true
+			}
+		}
+	}
+`, &cache.Config{Name: "getecache", ProviderName: "redis1"}).(Cache)
+	v, err = c.GetE("key1")
+	assert.NotNil(t, err)
+	assert.NotEqual(t, ErrCacheMiss, err)
+	assert.Nil(t, v)
+}
+
+func TestRedisPutIfAbsentAndPresent(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "putifcache", ProviderName: "redis1"}).(Cache)
+
+	stored, err := c.PutIfPresent("key1", "value1", 10*time.Second)
+	assert.Nil(t, err)
+	assert.False(t, stored)
+	assert.False(t, c.Exists("key1"))
+
+	stored, err = c.PutIfAbsent("key1", "value1", 10*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, stored)
+	stored, err = c.PutIfAbsent("key1", "value2", 10*time.Second)
+	assert.Nil(t, err)
+	assert.False(t, stored)
+	assert.Equal(t, "value1", c.Get("key1"))
+
+	stored, err = c.PutIfPresent("key1", "value3", 10*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, stored)
+	assert.Equal(t, "value3", c.Get("key1"))
+
+	// Put overwrites the existing entry
+	assert.Nil(t, c.Put("key1", "value4", 10*time.Second))
+	assert.Equal(t, "value4", c.Get("key1"))
+
+	assert.Equal(t, int64(3), c.Stats().Puts)
+	assert.Nil(t, c.Flush())
+}
+
 func TestRedisInvalidProviderName(t *testing.T) {
 	mgr := cache.NewManager()
 	mgr.AddProvider("redis1", new(Provider))
@@ -210,12 +364,331 @@ func TestRedisInvalidAddress(t *testing.T) {
 	assert.Equal(t, errors.New("aah/cache/redis1: dial tcp: address 637967: invalid port"), err)
 }
 
+func TestRedisProviderWithClient(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	p := ProviderWithClient(client)
+
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.Nil(t, p.clientOpts)
+	assert.True(t, p.Client() == client)
+	assert.True(t, p.UniversalClient() == client)
+
+	err := mgr.CreateCache(&cache.Config{Name: "clientcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("clientcache")
+	assert.Nil(t, c.Put("client-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("client-key1"))
+	assert.Nil(t, c.Flush())
+
+	ring := ProviderWithClient(redis.NewRing(&redis.RingOptions{
+		Addrs: map[string]string{"shard1": "localhost:6379"},
+	}))
+	assert.Nil(t, ring.Client())
+	assert.NotNil(t, ring.PoolStats())
+}
+
+func TestRedisConnectionURL(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:637967"
+			url = "redis://localhost:6379/1"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.Equal(t, "localhost:6379", p.clientOpts.Addr)
+	assert.Equal(t, 1, p.clientOpts.DB)
+	assert.Nil(t, p.clientOpts.TLSConfig)
+
+	mgr = cache.NewManager()
+	mgr.AddProvider("redis1", new(Provider))
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			url = "http://localhost:6379"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	assert.NotNil(t, mgr.InitProviders(cfg, l))
+}
+
+func TestRedisLazyConnect(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6390"
+			timeout {
+				connect = "100ms"
+			}
+			connect {
+				lazy = true
+				retry_backoff {
+					min = "50ms"
+					max = "100ms"
+				}
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.False(t, p.Connected())
+
+	err := mgr.CreateCache(&cache.Config{Name: "lazycache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("lazycache")
+	assert.NotNil(t, c.Put("lazy-key1", "value1", 3*time.Second))
+	assert.Nil(t, c.Get("lazy-key1"))
+}
+
+func TestRedisProviderClose(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			local {
+				enable = true
+			}
+			metrics {
+				enable = true
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	err := mgr.CreateCache(&cache.Config{Name: "closecache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+
+	assert.Nil(t, p.Close())
+	assert.Nil(t, p.Close())
+	assert.NotNil(t, p.HealthCheck())
+
+	// supplied client is not closed
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	mgr = cache.NewManager()
+	mgr.AddProvider("redis2", ProviderWithClient(client))
+	cfg, _ := config.ParseString(`cache {
+		redis2 {
+			provider = "redis"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.Nil(t, mgr.Provider("redis2").(*Provider).Close())
+	assert.Nil(t, client.Ping().Err())
+	assert.Nil(t, client.Close())
+}
+
+func TestRedisCacheIsolation(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				dbcache {
+					db = 2
+				}
+				nscache {
+					namespace = "myapp:"
+				}
+			}
+		}
+	}
+`)
+	for _, name := range []string{"dbcache", "nscache", "sharedcache"} {
+		err := mgr.CreateCache(&cache.Config{Name: name, ProviderName: "redis1"})
+		assert.Nil(t, err, "unable to create cache")
+	}
+	dbc := mgr.Cache("dbcache").(*redisCache)
+	nsc := mgr.Cache("nscache").(*redisCache)
+	sc := mgr.Cache("sharedcache").(*redisCache)
+	assert.True(t, dbc.ownsDB())
+	assert.Equal(t, 2, dbc.client().(*redis.Client).Options().DB)
+	assert.Equal(t, "myapp:nscache-", nsc.keyPrefix)
+	assert.False(t, sc.ownsDB())
+
+	for _, c := range []*redisCache{dbc, nsc, sc} {
+		assert.Nil(t, c.Put("iso-key1", "value1", 10*time.Second))
+	}
+	p := mgr.Provider("redis1").(*Provider)
+	assert.Equal(t, int64(0), p.Client().Exists("dbcache-iso-key1").Val())
+	assert.Equal(t, int64(1), p.Client().Exists("myapp:nscache-iso-key1").Val())
+
+	// scoped flush does not touch the sibling caches
+	assert.Nil(t, sc.Flush())
+	assert.Nil(t, sc.Get("iso-key1"))
+	assert.Equal(t, "value1", nsc.Get("iso-key1"))
+	assert.Equal(t, "value1", dbc.Get("iso-key1"))
+
+	assert.Nil(t, dbc.Flush())
+	assert.Nil(t, dbc.Get("iso-key1"))
+	assert.Equal(t, "value1", nsc.Get("iso-key1"))
+
+	assert.Nil(t, nsc.Flush())
+	assert.Nil(t, p.Close())
+}
+
+func TestRedisCacheOverrides(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				overridecache {
+					eviction_mode = "slide"
+					pool_size = 5
+					timeout {
+						read = "1s"
+					}
+				}
+				invalidcache {
+					eviction_mode = "lru"
+				}
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	err := mgr.CreateCache(&cache.Config{Name: "overridecache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	err = mgr.CreateCache(&cache.Config{Name: "defaultcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+
+	oc := mgr.Cache("overridecache").(*redisCache)
+	assert.Equal(t, "overridecache", oc.Name())
+	assert.Equal(t, cache.EvictionModeSlide, oc.cfg.EvictionMode)
+	assert.False(t, oc.ownsDB())
+	opts := oc.client().(*redis.Client).Options()
+	assert.Equal(t, 5, opts.PoolSize)
+	assert.Equal(t, time.Second, opts.ReadTimeout)
+	assert.Equal(t, p.clientOpts.WriteTimeout, opts.WriteTimeout)
+
+	dc := mgr.Cache("defaultcache").(*redisCache)
+	assert.Equal(t, "defaultcache", dc.Name())
+	assert.Equal(t, cache.EvictionModeTTL, dc.cfg.EvictionMode)
+	assert.True(t, dc.cref == p.cref)
+
+	err = mgr.CreateCache(&cache.Config{Name: "invalidcache", ProviderName: "redis1"})
+	assert.Equal(t, errors.New("aah/cache/invalidcache: unsupported eviction mode 'lru'"), err)
+
+	assert.Nil(t, p.Close())
+}
+
+func TestRedisGetOrPutE(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "getorputcache", ProviderName: "redis1"}).(Cache)
+
+	v, stored, err := c.GetOrPutE("key1", "value1", 10*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, stored)
+	assert.Equal(t, "value1", v)
+
+	v, stored, err = c.GetOrPutE("key1", "value2", 10*time.Second)
+	assert.Nil(t, err)
+	assert.False(t, stored)
+	assert.Equal(t, "value1", v)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisGetIfExists(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "getifexistscache", ProviderName: "redis1"}).(Cache)
+
+	c.SetLoader(func(k string) (interface{}, time.Duration, error) {
+		return "loaded", time.Minute, nil
+	})
+	v, found := c.GetIfExists("key1")
+	assert.False(t, found)
+	assert.Nil(t, v)
+	assert.False(t, c.Exists("key1"))
+
+	assert.Nil(t, c.Put("key1", false, 10*time.Second))
+	v, found = c.GetIfExists("key1")
+	assert.True(t, found)
+	assert.Equal(t, false, v)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisPoolTuning(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			max_retries = 3
+			pool {
+				min_idle = 2
+				max_conn_age = "30m"
+			}
+			caches {
+				tunedcache {
+					pool.max_conn_age = "1m"
+					max_retries = 1
+				}
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	assert.Equal(t, 3, p.clientOpts.MaxRetries)
+	assert.Equal(t, 2, p.clientOpts.MinIdleConns)
+	assert.Equal(t, 30*time.Minute, p.clientOpts.MaxConnAge)
+
+	err := mgr.CreateCache(&cache.Config{Name: "tunedcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	opts := mgr.Cache("tunedcache").(*redisCache).client().(*redis.Client).Options()
+	// retried by the provider retry policy
+	assert.Equal(t, 0, opts.MaxRetries)
+	assert.Equal(t, 2, opts.MinIdleConns)
+	assert.Equal(t, time.Minute, opts.MaxConnAge)
+
+	assert.Nil(t, p.Close())
+}
+
+func TestEscapeGlob(t *testing.T) {
+	assert.Equal(t, "cache1-", escapeGlob("cache1-"))
+	assert.Equal(t, `c\*a\?c\[h\]e\\-`, escapeGlob(`c*a?c[h]e\-`))
+}
+
 func TestParseTimeDuration(t *testing.T) {
 	d := parseDuration("", "1m")
 	assert.Equal(t, float64(1), d.Minutes())
+
+	assert.Equal(t, time.Duration(0), ttlValue(-2*time.Second))
+	assert.Equal(t, time.Duration(-1), ttlValue(-1))
+	assert.Equal(t, 5*time.Second, ttlValue(5*time.Second))
 }
 
-func createCacheMgr(t *testing.T, name, appCfgStr string) *cache.Manager {
+func createCacheMgr(t testing.TB, name, appCfgStr string) *cache.Manager {
 	mgr := cache.NewManager()
 	mgr.AddProvider(name, new(Provider))
 
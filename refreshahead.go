@@ -0,0 +1,209 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// refresher struct implements refresh-ahead of the registered cache entries.
+// Background goroutine checks the remaining time to live of the registered
+// keys every interval and reloads the entries expiring within the window
+// using the loader, so that the hot keys are always warm.
+//
+// Keys are registered explicitly via `RefreshAhead` or matched against the
+// patterns registered via `RefreshAheadPattern` on Get and Put. Explicitly
+// registered keys are loaded even if the entry does not exist, matched keys
+// are forgotten once the entry is gone.
+type refresher struct {
+	r           *redisCache
+	mu          sync.Mutex
+	keys        map[string]bool
+	patterns    []string
+	window      time.Duration
+	interval    time.Duration
+	concurrency int
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+func (p *Provider) newRefresher(r *redisCache) (*refresher, error) {
+	cacheName := r.Name()
+	ra := &refresher{
+		r:           r,
+		keys:        make(map[string]bool),
+		window:      parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "refresh_ahead.window"), "10s"), "10s"),
+		interval:    parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "refresh_ahead.interval"), "1s"), "1s"),
+		concurrency: p.appCfg.IntDefault(p.cacheCfgKey(cacheName, "refresh_ahead.concurrency"), 4),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	if ra.window <= 0 || ra.interval <= 0 || ra.concurrency <= 0 {
+		return nil, fmt.Errorf("window, interval and concurrency must be positive")
+	}
+	go ra.run()
+	return ra, nil
+}
+
+// RefreshAhead method registers the keys for refresh-ahead, entries are
+// reloaded using the loader shortly before they expire. It's enabled per
+// cache via config `refresh_ahead.enable = true`.
+//
+//	c.SetLoader(loadProduct)
+//	err := c.RefreshAhead("product-1", "product-2")
+func (r *redisCache) RefreshAhead(keys ...string) error {
+	if r.ra == nil {
+		return fmt.Errorf("aah/cache/%s: refresh_ahead is not enabled", r.Name())
+	}
+	r.ra.mu.Lock()
+	for _, k := range keys {
+		r.ra.keys[k] = true
+	}
+	r.ra.mu.Unlock()
+	return nil
+}
+
+// RefreshAheadPattern method registers the glob patterns for refresh-ahead,
+// keys of the cache entries read or written matching the pattern are
+// refreshed shortly before they expire, e.g. `product-*`.
+func (r *redisCache) RefreshAheadPattern(patterns ...string) error {
+	if r.ra == nil {
+		return fmt.Errorf("aah/cache/%s: refresh_ahead is not enabled", r.Name())
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("aah/cache/%s: pattern(%s) %v", r.Name(), pattern, err)
+		}
+	}
+	r.ra.mu.Lock()
+	r.ra.patterns = append(r.ra.patterns, patterns...)
+	r.ra.mu.Unlock()
+	return nil
+}
+
+// StopRefreshAhead method unregisters the keys from refresh-ahead.
+func (r *redisCache) StopRefreshAhead(keys ...string) {
+	if r.ra == nil {
+		return
+	}
+	r.ra.mu.Lock()
+	for _, k := range keys {
+		delete(r.ra.keys, k)
+	}
+	r.ra.mu.Unlock()
+}
+
+// touch method registers the key if it matches the registered patterns.
+func (ra *refresher) touch(k string) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	if _, found := ra.keys[k]; found {
+		return
+	}
+	for _, pattern := range ra.patterns {
+		if matched, _ := path.Match(pattern, k); matched {
+			ra.keys[k] = false
+			return
+		}
+	}
+}
+
+func (ra *refresher) run() {
+	defer close(ra.done)
+	ticker := time.NewTicker(ra.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ra.stop:
+			return
+		case <-ticker.C:
+			ra.refresh()
+		}
+	}
+}
+
+// refresh method reloads the registered entries expiring within the window,
+// at most concurrency entries are loaded in parallel.
+func (ra *refresher) refresh() {
+	r := ra.r
+	if r.loader == nil || r.circuitOpen() {
+		return
+	}
+	ra.mu.Lock()
+	keys := make([]string, 0, len(ra.keys))
+	for k := range ra.keys {
+		keys = append(keys, k)
+	}
+	ra.mu.Unlock()
+	if len(keys) == 0 {
+		return
+	}
+
+	cmds := make([]*redis.DurationCmd, len(keys))
+	_, err := r.client().Pipelined(func(pipe redis.Pipeliner) error {
+		for i, k := range keys {
+			cmds[i] = pipe.PTTL(r.key(k))
+		}
+		return nil
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.logger.errorf("aah/cache/%s: refresh_ahead %v", r.Name(), err)
+		return
+	}
+
+	sem := make(chan struct{}, ra.concurrency)
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		ttl := ttlValue(cmds[i].Val())
+		if ttl == 0 && !ra.explicit(k) {
+			ra.forget(k)
+			continue
+		}
+		if ttl < 0 || ttl > ra.window {
+			continue
+		}
+		select {
+		case <-ra.stop:
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(k string) {
+			defer func() { <-sem; wg.Done() }()
+			if _, err := r.loadAndPut(context.Background(), k, func() (interface{}, time.Duration, error) { return r.loader(k) }); err != nil {
+				r.logFor(opPut, k).errorf("aah/cache/%s: key(%s) refresh_ahead %v", r.Name(), k, err)
+			}
+		}(k)
+	}
+	wg.Wait()
+}
+
+func (ra *refresher) explicit(k string) bool {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	return ra.keys[k]
+}
+
+func (ra *refresher) forget(k string) {
+	ra.mu.Lock()
+	if !ra.keys[k] {
+		delete(ra.keys, k)
+	}
+	ra.mu.Unlock()
+}
+
+func (ra *refresher) close() {
+	close(ra.stop)
+	<-ra.done
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisRefreshAhead(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				refreshcache {
+					refresh_ahead {
+						enable = true
+						window = "2s"
+						interval = "100ms"
+						concurrency = 2
+					}
+				}
+			}
+		}
+	}
+`, &cache.Config{Name: "refreshcache", ProviderName: "redis1"}).(Cache)
+
+	var loads int32
+	c.SetLoader(func(k string) (interface{}, time.Duration, error) {
+		n := atomic.AddInt32(&loads, 1)
+		return n, 3 * time.Second, nil
+	})
+
+	// explicitly registered key is loaded even if it does not exist
+	assert.Nil(t, c.RefreshAhead("hot-1"))
+	time.Sleep(300 * time.Millisecond)
+	assert.True(t, c.Exists("hot-1"))
+
+	// matched key is refreshed before expiry
+	assert.Nil(t, c.RefreshAheadPattern("product-*"))
+	assert.NotNil(t, c.RefreshAheadPattern("product-["))
+	assert.Nil(t, c.Put("product-1", "v1", 3*time.Second))
+	assert.Nil(t, c.Put("order-1", "v1", 3*time.Second))
+	time.Sleep(1500 * time.Millisecond)
+	assert.NotEqual(t, "v1", c.Get("product-1"))
+	assert.Equal(t, "v1", c.Get("order-1"))
+	ttl, err := c.TTL("product-1")
+	assert.Nil(t, err)
+	assert.True(t, ttl > 2*time.Second)
+
+	c.StopRefreshAhead("hot-1")
+	assert.Nil(t, c.Flush())
+
+	// not enabled
+	c2 := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "norefreshcache", ProviderName: "redis1"}).(Cache)
+	assert.NotNil(t, c2.RefreshAhead("hot-1"))
+}
@@ -0,0 +1,170 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"aahframe.work/config"
+	"github.com/go-redis/redis"
+)
+
+// clientRef struct holds the Redis client, it's swapped on `Reload` while the
+// cache operations are in-flight.
+type clientRef struct {
+	v atomic.Value
+}
+
+// clientHolder struct wraps the client, since `atomic.Value` requires the
+// same concrete type on every store.
+type clientHolder struct {
+	c Commander
+}
+
+func newClientRef(c Commander) *clientRef {
+	cr := &clientRef{}
+	cr.store(c)
+	return cr
+}
+
+func (cr *clientRef) load() Commander {
+	return cr.v.Load().(clientHolder).c
+}
+
+func (cr *clientRef) store(c Commander) {
+	cr.v.Store(clientHolder{c: c})
+}
+
+func (p *Provider) client() Commander {
+	return p.cref.load()
+}
+
+func (r *redisCache) client() Commander {
+	return r.cref.load()
+}
+
+// Reload method re-reads the provider config `cache.<provider>.*` from given
+// config and rebuilds the Redis clients, e.g. to rotate the password or to
+// resize the pool without restarting the application. New client is verified
+// using PING before it's swapped in, on failure the current clients are kept.
+// Previous clients are closed after config `reload.drain_timeout`, default is
+// `5s`, so the in-flight operations could complete. Invalidation and eviction
+// subscriptions are re-established on the new client.
+//
+// Connection settings, addresses, the replica connection settings and the
+// sizes of the read and blocking pools are reloaded; other settings of the
+// existing caches are not, and the pools are not added or removed. Client
+// supplied via `ProviderWithClient` is not reloaded, its owner is responsible
+// for it.
+//
+//	aah.App().OnConfigHotReload(func(e *aah.Event) {
+//		if err := p.Reload(aah.App().Config()); err != nil {
+//			aah.App().Log().Error(err)
+//		}
+//	})
+func (p *Provider) Reload(appCfg *config.Config) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return fmt.Errorf("aah/cache/%s: reload on closed provider", p.name)
+	}
+	if !p.ownsClient {
+		return fmt.Errorf("aah/cache/%s: reload is not supported for the supplied client", p.name)
+	}
+
+	prevCfg := p.appCfg
+	p.appCfg = appCfg
+	clients, opts, cs, err := p.reloadClients()
+	if err != nil {
+		for _, c := range clients {
+			_ = c.Close()
+		}
+		p.appCfg = prevCfg
+		return fmt.Errorf("aah/cache/%s: reload %v", p.name, err)
+	}
+	p.clientOpts = opts
+	p.setConnSettings(cs)
+
+	stale := []Commander{p.client()}
+	p.cref.store(clients[p.cref])
+	for _, r := range p.caches {
+		if c, found := clients[r.cref]; found && r.cref != p.cref {
+			stale = append(stale, r.client())
+			r.cref.store(c)
+		}
+	}
+	if p.replicas != nil {
+		if rs := p.newReplicas(); rs != nil {
+			for _, c := range p.replicas.swap(rs.load()) {
+				stale = append(stale, c)
+			}
+		}
+	}
+	if p.pools != nil {
+		if ps := p.newPools(); ps != nil {
+			stale = append(stale, p.pools.swap(ps)...)
+		}
+	}
+	for _, r := range p.caches {
+		if r.inv != nil {
+			if err := r.inv.resubscribe(); err != nil {
+				r.logger.errorf("aah/cache/%s: invalidation resubscribe %v", r.Name(), err)
+			}
+		}
+		if r.el != nil {
+			if err := r.el.resubscribe(); err != nil {
+				r.logger.errorf("aah/cache/%s: eviction resubscribe %v", r.Name(), err)
+			}
+		}
+	}
+
+	drain := parseDuration(p.appCfg.StringDefault(p.cfgPrefix+"reload.drain_timeout", "5s"), "5s")
+	go func() {
+		select {
+		case <-time.After(drain):
+		case <-p.closing:
+		}
+		for _, c := range stale {
+			_ = c.Close()
+		}
+	}()
+	p.logger.Infof("aah/cache/provider: %s reloaded successfully", p.name)
+	return nil
+}
+
+// reloadClients method creates the provider client and the clients of the
+// caches with overridden connection settings from the current config, keyed
+// by the client reference they replace, along with the client options and
+// the connection settings to commit. Provider client is verified using PING,
+// provider fields are not modified.
+func (p *Provider) reloadClients() (map[*clientRef]redis.UniversalClient, *redis.Options, connSettings, error) {
+	clients := make(map[*clientRef]redis.UniversalClient)
+	c, opts, cs, _, err := p.newClient()
+	if err != nil {
+		return clients, nil, cs, err
+	}
+	clients[p.cref] = c
+	if err = c.Ping().Err(); err != nil {
+		return clients, nil, cs, err
+	}
+
+	for _, r := range p.caches {
+		if r.cref == p.cref {
+			continue
+		}
+		copts, err := p.cacheClientOptions(r.Name(), opts, cs)
+		if err != nil {
+			return clients, nil, cs, err
+		}
+		if copts == nil {
+			o := *opts
+			copts = &o
+		}
+		clients[r.cref] = p.newRedisClient(copts)
+	}
+	return clients, opts, cs, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisReload(t *testing.T) {
+	p := new(Provider)
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			pool_size = 5
+			reload {
+				drain_timeout = "100ms"
+			}
+			caches {
+				reloaddbcache {
+					db = 2
+				}
+			}
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "reloadcache", ProviderName: "redis1"}))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "reloaddbcache", ProviderName: "redis1"}))
+	c := mgr.Cache("reloadcache").(*redisCache)
+	dc := mgr.Cache("reloaddbcache").(*redisCache)
+	assert.Nil(t, c.Put("reload-key1", "value1", time.Minute))
+	assert.Nil(t, dc.Put("reload-key1", "value2", time.Minute))
+	prev := p.Client()
+
+	cfg, _ = config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			pool_size = 20
+			reload {
+				drain_timeout = "100ms"
+			}
+			caches {
+				reloaddbcache {
+					db = 2
+					pool_size = 3
+				}
+			}
+		}
+	}`)
+	assert.Nil(t, p.Reload(cfg))
+	assert.False(t, prev == p.Client())
+	assert.Equal(t, 20, p.Client().Options().PoolSize)
+	assert.Equal(t, 3, dc.client().(*redis.Client).Options().PoolSize)
+	assert.Equal(t, 2, dc.client().(*redis.Client).Options().DB)
+	assert.Equal(t, "value1", c.Get("reload-key1"))
+	assert.Equal(t, "value2", dc.Get("reload-key1"))
+
+	// previous client is closed after the drain timeout
+	assert.Nil(t, prev.Ping().Err())
+	time.Sleep(200 * time.Millisecond)
+	assert.NotNil(t, prev.Ping().Err())
+
+	// unreachable server keeps the current client
+	current := p.Client()
+	cfg, _ = config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6390"
+			timeout {
+				connect = "100ms"
+			}
+		}
+	}`)
+	err := p.Reload(cfg)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "aah/cache/redis1: reload")
+	assert.True(t, current == p.Client())
+	assert.Equal(t, "value1", c.Get("reload-key1"))
+
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, dc.Flush())
+	assert.Nil(t, p.Close())
+	assert.NotNil(t, p.Reload(cfg))
+}
+
+func TestRedisReloadSuppliedClient(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer client.Close()
+	p := ProviderWithClient(client)
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+
+	err := p.Reload(cfg)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not supported for the supplied client")
+}
+
+func TestRedisReloadFailureKeepsSettings(t *testing.T) {
+	p := new(Provider)
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", p)
+	cfg, _ := config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			client_name = "reload-test"
+		}
+	}`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "reloadfailcache", ProviderName: "redis1"}))
+	c := mgr.Cache("reloadfailcache").(*redisCache)
+	prev := p.connSettings()
+
+	cfg, _ = config.ParseString(`cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6390"
+			db = 3
+			username = "reload-user"
+			password = "reload-password"
+			client_name = "reload-other"
+			timeout {
+				connect = "100ms"
+			}
+		}
+	}`)
+	assert.NotNil(t, p.Reload(cfg))
+	assert.Equal(t, prev, p.connSettings())
+	assert.Equal(t, "localhost:6379", p.address)
+	assert.Equal(t, 0, p.db)
+	assert.Equal(t, "", p.username)
+	assert.Equal(t, "", p.password)
+
+	// connections of the current client use the current settings
+	name, err := p.Client().ClientGetName().Result()
+	assert.Nil(t, err)
+	assert.Equal(t, "reload-test", name)
+
+	assert.Nil(t, c.Put("reload-fail-key", "value1", time.Minute))
+	assert.Equal(t, "value1", c.Get("reload-fail-key"))
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, p.Close())
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync/atomic"
+
+	"github.com/go-redis/redis"
+)
+
+// replicas struct holds the clients of the Redis replicas configured via
+// `replica.addresses` in standalone mode, reads are distributed in round-robin.
+// In cluster mode, use `cluster.read_from_replicas` instead. Reads from
+// replicas could be stale due to the replication lag.
+type replicas struct {
+	next    uint32
+	clients atomic.Value // []*redis.Client
+}
+
+func (p *Provider) newReplicas() *replicas {
+	addresses, found := p.appCfg.StringList(p.cfgPrefix + "replica.addresses")
+	if !found || len(addresses) == 0 || p.clientOpts == nil {
+		return nil
+	}
+	clients := make([]*redis.Client, 0, len(addresses))
+	for _, addr := range addresses {
+		opts := *p.clientOpts
+		opts.Addr = addr
+		clients = append(clients, p.newRedisClient(&opts))
+	}
+	rs := &replicas{}
+	rs.clients.Store(clients)
+	return rs
+}
+
+func (rs *replicas) load() []*redis.Client {
+	return rs.clients.Load().([]*redis.Client)
+}
+
+// swap method replaces the replica clients and returns the previous ones.
+func (rs *replicas) swap(clients []*redis.Client) []*redis.Client {
+	return rs.clients.Swap(clients).([]*redis.Client)
+}
+
+func (rs *replicas) client() *redis.Client {
+	clients := rs.load()
+	return clients[int(atomic.AddUint32(&rs.next, 1)-1)%len(clients)]
+}
+
+func (rs *replicas) close() []string {
+	var errs []string
+	for _, c := range rs.load() {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return errs
+}
+
+// read method performs the read command on replica if the cache reads from
+// replicas, on replica failure it's retried on the primary. Cache miss is not
+// a failure.
+func (r *redisCache) read(fn func(c Commander) error) error {
+	if r.replicas != nil {
+		if err := fn(r.replicas.client()); notacacheMiss(err) == nil {
+			return err
+		}
+	}
+	return fn(r.readClient())
+}
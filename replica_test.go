@@ -0,0 +1,51 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisReadFromReplica(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			timeout.connect = "50ms"
+			replica {
+				addresses = ["localhost:6379", "localhost:6390"]
+			}
+			caches {
+				primarycache {
+					replica.read = false
+				}
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "replicacache", ProviderName: "redis1"}))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "primarycache", ProviderName: "redis1"}))
+	c := mgr.Cache("replicacache")
+	assert.NotNil(t, c.(*redisCache).replicas)
+	assert.Len(t, c.(*redisCache).replicas.clients, 2)
+	assert.Nil(t, mgr.Cache("primarycache").(*redisCache).replicas)
+
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	// unavailable replica is retried on primary
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, "value1", c.Get("key1"))
+		assert.True(t, c.Exists("key1"))
+		assert.Nil(t, c.Get("notexists"))
+	}
+	assert.Equal(t, uint64(0), c.(Cache).Stats().Errors)
+
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, mgr.Provider("redis1").(*Provider).Close())
+}
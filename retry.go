@@ -0,0 +1,108 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// readCommands are the Redis commands retried on transient failure, retrying
+// them is safe since they do not change the data.
+var readCommands = map[string]bool{
+	"get": true, "mget": true, "getrange": true, "strlen": true, "exists": true,
+	"ttl": true, "pttl": true, "type": true, "scan": true, "dbsize": true,
+	"hget": true, "hmget": true, "hgetall": true, "hexists": true, "hlen": true,
+	"lrange": true, "llen": true, "lindex": true, "getbit": true, "bitcount": true,
+	"pfcount": true, "object": true, "memory": true, "ping": true, "info": true,
+}
+
+// retryErrPrefixes are the Redis error prefixes of the transient server state.
+var retryErrPrefixes = []string{"LOADING ", "READONLY ", "CLUSTERDOWN ", "TRYAGAIN "}
+
+// newRedisClient method creates the Redis client with the provider retry
+// policy, see `retryProcess`.
+func (p *Provider) newRedisClient(opts *redis.Options) *redis.Client {
+	o := *opts
+	o.MaxRetries = 0
+	c := redis.NewClient(&o)
+	p.wrapRetry(c, opts.MaxRetries, opts.MinRetryBackoff, opts.MaxRetryBackoff)
+	return c
+}
+
+// wrapRetry method applies the provider retry policy to the client. Config
+// `max_retries` is the number of retries of the read commands on transient
+// failure, i.e. network errors and Redis `LOADING`, `READONLY`, `CLUSTERDOWN`
+// and `TRYAGAIN` errors, with exponential backoff between `retry_backoff.min`
+// and `retry_backoff.max`. Writes are never retried, since the failed write
+// could have been applied by Redis, e.g. `INCR` or `LPUSH` retried after the
+// read timeout applies twice. Pipelines and transactions are not retried.
+// Retry count is exposed via `Provider.Retries` and the `retries_total`
+// metric.
+func (p *Provider) wrapRetry(c redis.UniversalClient, maxRetries int, minBackoff, maxBackoff time.Duration) {
+	if maxRetries <= 0 {
+		return
+	}
+	c.WrapProcess(func(process func(cmd redis.Cmder) error) func(cmd redis.Cmder) error {
+		return func(cmd redis.Cmder) error {
+			err := process(cmd)
+			if !readCommands[strings.ToLower(cmd.Name())] {
+				return err
+			}
+			for attempt := 0; attempt < maxRetries && retryable(err); attempt++ {
+				time.Sleep(retryBackoff(attempt, minBackoff, maxBackoff))
+				atomic.AddUint64(&p.retries, 1)
+				p.logger.Debugf("aah/cache/provider: %s retry %d of %s: %v", p.name, attempt+1, cmd.Name(), err)
+				err = process(cmd)
+			}
+			return err
+		}
+	})
+}
+
+// Retries method returns the number of the read commands retried by the
+// provider retry policy.
+func (p *Provider) Retries() uint64 {
+	return atomic.LoadUint64(&p.retries)
+}
+
+// retryable returns true if the error is transient, cache miss is not.
+func retryable(err error) bool {
+	if err == nil || err == redis.Nil {
+		return false
+	}
+	if err == io.EOF {
+		return true
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	s := err.Error()
+	for _, prefix := range retryErrPrefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryBackoff returns the exponential backoff of the retry attempt with
+// jitter, bounded by min and max.
+func retryBackoff(attempt int, min, max time.Duration) time.Duration {
+	if min <= 0 {
+		return 0
+	}
+	d := max
+	if b := min << uint(attempt); attempt < 32 && b > 0 && b < max {
+		d = b
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
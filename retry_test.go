@@ -0,0 +1,61 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisRetryPolicy(t *testing.T) {
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	p := &Provider{name: "redis1", logger: l}
+	c := p.newRedisClient(&redis.Options{
+		Addr:            "localhost:6390",
+		DialTimeout:     50 * time.Millisecond,
+		MaxRetries:      2,
+		MinRetryBackoff: time.Millisecond,
+		MaxRetryBackoff: 4 * time.Millisecond,
+	})
+	defer c.Close()
+	assert.Equal(t, 0, c.Options().MaxRetries)
+
+	// reads are retried
+	assert.NotNil(t, c.Get("retry-key1").Err())
+	assert.Equal(t, uint64(2), p.Retries())
+
+	// writes are not retried
+	assert.NotNil(t, c.Incr("retry-key1").Err())
+	assert.NotNil(t, c.Set("retry-key1", "value1", 0).Err())
+	assert.Equal(t, uint64(2), p.Retries())
+}
+
+func TestRetryable(t *testing.T) {
+	assert.False(t, retryable(nil))
+	assert.False(t, retryable(redis.Nil))
+	assert.False(t, retryable(errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")))
+	assert.True(t, retryable(io.EOF))
+	assert.True(t, retryable(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.True(t, retryable(errors.New("LOADING Redis is loading the dataset in memory")))
+	assert.True(t, retryable(errors.New("READONLY You can't write against a read only replica.")))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), retryBackoff(0, 0, time.Second))
+	for attempt := 0; attempt < 70; attempt++ {
+		d := retryBackoff(attempt, 8*time.Millisecond, 512*time.Millisecond)
+		assert.True(t, d >= 4*time.Millisecond && d <= 512*time.Millisecond, d)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+
+	"github.com/go-redis/redis"
+)
+
+// newRing method creates the Redis Ring client for config `mode = "ring"`,
+// keys are consistently hashed across the standalone Redis servers of config
+// `addresses`. Shards are named by its address, so that the key distribution
+// stays the same on reordering the addresses. Connection settings are the
+// same as standalone mode.
+//
+//	mode = "ring"
+//	addresses = ["redis1:6379", "redis2:6379", "redis3:6379"]
+func (p *Provider) newRing(opts *redis.Options) (*redis.Ring, error) {
+	addresses, found := p.appCfg.StringList(p.cfgPrefix + "addresses")
+	if !found || len(addresses) == 0 {
+		return nil, errors.New("ring mode requires 'addresses'")
+	}
+
+	ropts := &redis.RingOptions{
+		Addrs:              make(map[string]string, len(addresses)),
+		HeartbeatFrequency: parseDuration(p.appCfg.StringDefault(p.cfgPrefix+"ring.heartbeat_frequency", "500ms"), "500ms"),
+		OnConnect:          opts.OnConnect,
+		DB:                 opts.DB,
+		Password:           opts.Password,
+		MinRetryBackoff:    opts.MinRetryBackoff,
+		MaxRetryBackoff:    opts.MaxRetryBackoff,
+		DialTimeout:        opts.DialTimeout,
+		ReadTimeout:        opts.ReadTimeout,
+		WriteTimeout:       opts.WriteTimeout,
+		PoolSize:           opts.PoolSize,
+		PoolTimeout:        opts.PoolTimeout,
+		MinIdleConns:       opts.MinIdleConns,
+		MaxConnAge:         opts.MaxConnAge,
+		IdleTimeout:        opts.IdleTimeout,
+		IdleCheckFrequency: opts.IdleCheckFrequency,
+	}
+	for _, addr := range addresses {
+		ropts.Addrs[addr] = addr
+	}
+	ring := redis.NewRing(ropts)
+	p.wrapRetry(ring, opts.MaxRetries, opts.MinRetryBackoff, opts.MaxRetryBackoff)
+	return ring, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisRingMode(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			mode = "ring"
+			addresses = ["localhost:6379"]
+			db = 2
+			caches {
+				poolcache {
+					pool_size = 5
+				}
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "ringcache", ProviderName: "redis1"}))
+	c := mgr.Cache("ringcache").(Cache)
+	p := mgr.Provider("redis1").(*Provider)
+	_, ok := p.UniversalClient().(*redis.Ring)
+	assert.True(t, ok)
+	assert.Nil(t, p.Client())
+
+	for i := 0; i < 20; i++ {
+		assert.Nil(t, c.Put(fmt.Sprintf("key%d", i), i, 10*time.Second))
+	}
+	assert.Equal(t, 5, c.Get("key5"))
+	n, err := c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), n)
+
+	assert.Nil(t, c.Flush())
+	n, err = c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	// connection settings override is not supported
+	err = mgr.CreateCache(&cache.Config{Name: "poolcache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/poolcache: connection settings override is supported only in standalone mode", err.Error())
+}
+
+func TestRedisRingModeInvalidConfig(t *testing.T) {
+	testcases := []struct {
+		cfg string
+		err string
+	}{
+		{
+			cfg: `
+	cache {
+		redis1 {
+			provider = "redis"
+			mode = "ring"
+		}
+	}`,
+			err: "aah/cache/redis1: ring mode requires 'addresses'",
+		},
+		{
+			cfg: `
+	cache {
+		redis1 {
+			provider = "redis"
+			mode = "sentinel"
+		}
+	}`,
+			err: "aah/cache/redis1: unsupported mode 'sentinel'",
+		},
+	}
+	for _, tc := range testcases {
+		mgr := cache.NewManager()
+		mgr.AddProvider("redis1", new(Provider))
+		cfg, _ := config.ParseString(tc.cfg)
+		l, _ := log.New(config.NewEmpty())
+		l.SetWriter(ioutil.Discard)
+		err := mgr.InitProviders(cfg, l)
+		assert.NotNil(t, err)
+		assert.Equal(t, tc.err, err.Error())
+	}
+}
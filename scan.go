@@ -0,0 +1,113 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// scanOptions struct holds the prefix-scoped operations (Flush,
+// DeleteByPattern, Size, Export, etc.) settings of the provider. Shards of
+// Redis Ring and master nodes of Redis Cluster are scanned in parallel up to
+// `scan.parallelism` (default 4, 1 scans one at a time), each with its own
+// SCAN cursor. SCAN calls per shard are limited to `scan.rate_limit` per
+// second (default 0, unlimited) to avoid blocking Redis, and `scan.count`
+// (default 1000) is the hint of number of keys returned per call.
+type scanOptions struct {
+	parallelism int
+	rateLimit   int
+	count       int64
+}
+
+func (p *Provider) newScanOptions() scanOptions {
+	so := scanOptions{
+		parallelism: p.appCfg.IntDefault(p.cfgPrefix+"scan.parallelism", 4),
+		rateLimit:   p.appCfg.IntDefault(p.cfgPrefix+"scan.rate_limit", 0),
+		count:       int64(p.appCfg.IntDefault(p.cfgPrefix+"scan.count", 1000)),
+	}
+	if so.parallelism <= 0 {
+		so.parallelism = 1
+	}
+	if so.count <= 0 {
+		so.count = 1000
+	}
+	return so
+}
+
+// scanKeys method iterates the keys matching the given glob-style pattern
+// using Redis SCAN and calls fn with each batch of keys and the client of the
+// Redis server holding the keys. fn is called concurrently for the different
+// shards.
+func (r *redisCache) scanKeys(pattern string, fn func(c Commander, keys []string) error) error {
+	so := r.p.scan
+	return r.forEachShard(func(c Commander) error {
+		var throttle <-chan time.Time
+		if so.rateLimit > 0 {
+			t := time.NewTicker(time.Second / time.Duration(so.rateLimit))
+			defer t.Stop()
+			throttle = t.C
+		}
+		var cursor uint64
+		for {
+			if throttle != nil && cursor != 0 {
+				<-throttle
+			}
+			keys, next, err := c.Scan(cursor, pattern, so.count).Result()
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				if err = fn(c, keys); err != nil {
+					return err
+				}
+			}
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	})
+}
+
+// deleteKeys method deletes the keys matching the given glob-style pattern.
+// In Redis Cluster mode, keys of the node could belong to different slots, so
+// they're deleted one by one in a pipeline.
+func (r *redisCache) deleteKeys(pattern string) error {
+	_, cluster := r.client().(*redis.ClusterClient)
+	return r.scanKeys(pattern, func(c Commander, keys []string) error {
+		if !cluster {
+			return c.Del(keys...).Err()
+		}
+		_, err := c.Pipelined(func(pipe redis.Pipeliner) error {
+			for _, k := range keys {
+				pipe.Del(k)
+			}
+			return nil
+		})
+		return err
+	})
+}
+
+// forEachShard method calls fn with each shard client in Redis Ring mode,
+// each master node client in Redis Cluster mode, otherwise with the cache
+// client. Up to `scan.parallelism` calls run concurrently.
+func (r *redisCache) forEachShard(fn func(c Commander) error) error {
+	sem := make(chan struct{}, r.p.scan.parallelism)
+	limited := func(c *redis.Client) error {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+		return fn(c)
+	}
+	switch c := r.client().(type) {
+	case *redis.Ring:
+		return c.ForEachShard(limited)
+	case *redis.ClusterClient:
+		return c.ForEachMaster(limited)
+	default:
+		return fn(c)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisScanOptions(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			scan {
+				parallelism = 0
+				rate_limit = 100
+				count = 10
+			}
+		}
+	}
+`, &cache.Config{Name: "scancache", ProviderName: "redis1"}).(Cache)
+	so := c.(*redisCache).p.scan
+	assert.Equal(t, scanOptions{parallelism: 1, rateLimit: 100, count: 10}, so)
+
+	for i := 0; i < 50; i++ {
+		assert.Nil(t, c.Put("scan-"+strconv.Itoa(i), i, time.Minute))
+	}
+	n, err := c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(50), n)
+
+	deleted, err := c.DeleteByPattern("scan-1*")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), deleted)
+
+	assert.Nil(t, c.Flush())
+	n, err = c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+}
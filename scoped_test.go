@@ -0,0 +1,123 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisScoped(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			key {
+				hash = "sha256"
+				hash_threshold = 16
+			}
+		}
+	}
+`, &cache.Config{Name: "tenantcache", ProviderName: "redis1"}).(Cache)
+
+	t1 := c.Scoped("t1")
+	t2 := c.Scoped("t2")
+	assert.Equal(t, "tenantcache", t1.Name())
+
+	longKey := strings.Repeat("k", 32)
+	assert.Nil(t, t1.Put("key1", "t1-value1", 10*time.Second))
+	assert.Nil(t, t1.Put(longKey, "t1-long", 10*time.Second))
+	assert.Nil(t, t2.Put("key1", "t2-value1", 10*time.Second))
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+
+	assert.Equal(t, "t1-value1", t1.Get("key1"))
+	assert.Equal(t, "t1-long", t1.Get(longKey))
+	assert.Equal(t, "t2-value1", t2.Get("key1"))
+	assert.Equal(t, "value1", c.Get("key1"))
+	assert.True(t, strings.HasPrefix(c.(*redisCache).key("tenant:t1:"+longKey), "tenantcache-tenant:t1:#"))
+
+	v, err := t2.GetOrPut("key2", "t2-value2", 10*time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "t2-value2", v)
+	assert.False(t, t1.Exists("key2"))
+
+	// tenant flush deletes only the tenant entries
+	assert.Nil(t, t1.Flush())
+	assert.False(t, t1.Exists("key1"))
+	assert.False(t, t1.Exists(longKey))
+	assert.True(t, t2.Exists("key1"))
+	assert.True(t, c.Exists("key1"))
+
+	assert.Nil(t, t2.Delete("key1"))
+	assert.False(t, t2.Exists("key1"))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisScopedTenantID(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "tenantidcache", ProviderName: "redis1"}).(Cache)
+
+	assert.Equal(t, "tenant:a%3Ab%2A%3F%5B%5D%5C%25:", c.Scoped(`a:b*?[]\%`).(*scopedCache).scope)
+
+	// tenant `a` is not a prefix of tenant `a:b`
+	ta, tab := c.Scoped("a"), c.Scoped("a:b")
+	assert.Nil(t, ta.Put("b:key1", "a-value1", 10*time.Second))
+	assert.Nil(t, tab.Put("key1", "ab-value1", 10*time.Second))
+	assert.Equal(t, "a-value1", ta.Get("b:key1"))
+	assert.Equal(t, "ab-value1", tab.Get("key1"))
+
+	// glob metacharacters are not matched on Flush
+	tg := c.Scoped("*")
+	assert.Nil(t, tg.Put("key1", "glob-value1", 10*time.Second))
+	assert.Nil(t, tg.Flush())
+	assert.False(t, tg.Exists("key1"))
+	assert.True(t, ta.Exists("b:key1"))
+
+	assert.Nil(t, ta.Flush())
+	assert.False(t, ta.Exists("b:key1"))
+	assert.True(t, tab.Exists("key1"))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisScopedWriteBehind(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			write_behind {
+				enable = true
+				flush_interval = "1h"
+				batch_size = 1000
+			}
+		}
+	}
+`, &cache.Config{Name: "tenantwbcache", ProviderName: "redis1"}).(*redisCache)
+
+	t1, t2 := c.Scoped("t1"), c.Scoped("t2")
+	assert.Nil(t, t1.Put("key1", "t1-value1", 10*time.Second))
+	assert.Nil(t, t2.Put("key1", "t2-value1", 10*time.Second))
+
+	// queued entries of the tenant are cancelled by its Flush
+	assert.Nil(t, t1.Flush())
+	c.wb.close()
+	assert.False(t, t1.Exists("key1"))
+	assert.Equal(t, "t2-value1", t2.Get("key1"))
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+
+	"github.com/go-redis/redis"
+)
+
+// RegisterScript method registers the Lua script with given name, registered
+// scripts are executed on the caches using `EvalScript`. Registering the
+// different source with the same name returns an error.
+//
+//	err := p.RegisterScript("incrby-capped", `local v = redis.call("incrby", KEYS[1], ARGV[1])
+//	if v > tonumber(ARGV[2]) then
+//		redis.call("set", KEYS[1], ARGV[2])
+//		return tonumber(ARGV[2])
+//	end
+//	return v`)
+func (p *Provider) RegisterScript(name, src string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, found := p.scripts[name]; found {
+		if s.Hash() == redis.NewScript(src).Hash() {
+			return nil
+		}
+		return fmt.Errorf("aah/cache/%s: script(%s) already registered", p.name, name)
+	}
+	if p.scripts == nil {
+		p.scripts = make(map[string]*redis.Script)
+	}
+	p.scripts[name] = redis.NewScript(src)
+	return nil
+}
+
+func (p *Provider) script(name string) *redis.Script {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.scripts[name]
+}
+
+// EvalScript method executes the script registered via
+// `Provider.RegisterScript` with given keys and args, it returns the script
+// result as is. Keys are cache keys, the cache key prefix is applied to it.
+// Script is executed using `EVALSHA` and it falls back to `EVAL` when the
+// script is not cached on the Redis server.
+//
+//	v, err := c.EvalScript("incrby-capped", []string{"hits"}, 1, 100)
+func (r *redisCache) EvalScript(name string, keys []string, args ...interface{}) (interface{}, error) {
+	s := r.p.script(name)
+	if s == nil {
+		return nil, fmt.Errorf("aah/cache/%s: script(%s) not registered", r.Name(), name)
+	}
+	if r.circuitOpen() {
+		r.stats.error()
+		return nil, fmt.Errorf("aah/cache/%s: script(%s) %v", r.Name(), name, ErrCircuitOpen)
+	}
+	rkeys := make([]string, len(keys))
+	for i, k := range keys {
+		rkeys[i] = r.key(k)
+	}
+	v, err := s.Run(r.client(), rkeys, args...).Result()
+	r.p.done(notacacheMiss(err))
+	if err != nil {
+		if err = notacacheMiss(err); err == nil {
+			return nil, nil
+		}
+		r.stats.error()
+		return nil, fmt.Errorf("aah/cache/%s: script(%s) %v", r.Name(), name, err)
+	}
+	if r.local != nil {
+		for _, k := range keys {
+			r.local.Delete(k)
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisScriptRegistry(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "scriptcache", ProviderName: "redis1"}))
+	p := mgr.Provider("redis1").(*Provider)
+	c := mgr.Cache("scriptcache").(Cache)
+
+	src := `local v = redis.call("incrby", KEYS[1], ARGV[1])
+if v > tonumber(ARGV[2]) then
+	redis.call("set", KEYS[1], ARGV[2])
+	return tonumber(ARGV[2])
+end
+return v`
+	assert.Nil(t, p.RegisterScript("incrby-capped", src))
+	assert.Nil(t, p.RegisterScript("incrby-capped", src))
+	assert.NotNil(t, p.RegisterScript("incrby-capped", "return 1"))
+
+	_, err := c.EvalScript("notexists", nil)
+	assert.NotNil(t, err)
+
+	// script not cached on server, falls back to EVAL
+	assert.Nil(t, p.Client().ScriptFlush().Err())
+	v, err := c.EvalScript("incrby-capped", []string{"hits"}, 2, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), v)
+	v, err = c.EvalScript("incrby-capped", []string{"hits"}, 2, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), v)
+
+	// key prefix is applied
+	assert.Equal(t, "3", p.Client().Get("scriptcache-hits").Val())
+
+	assert.Nil(t, p.RegisterScript("getnil", `return redis.call("get", KEYS[1])`))
+	v, err = c.EvalScript("getnil", []string{"notexists"})
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+
+	assert.Nil(t, c.Flush())
+}
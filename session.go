@@ -0,0 +1,92 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"aahframe.work/security/session"
+)
+
+var _ session.Storer = (*SessionStore)(nil)
+
+// SessionStore struct implements the aah session store `session.Storer`
+// backed by the cache of the provider, so the application uses the same Redis
+// integration for the cache and the sessions. Session values are stored as
+// the cache entries, so the key prefix, per-cache config, stats, metrics and
+// tracing of the cache apply to the sessions too.
+//
+// Sessions expire after `security.session.timeout`, zero timeout (the browser
+// session) uses `security.session.store.redis.timeout`, default is `24h`.
+// Set `security.session.store.redis.slide = true` to extend the expiry on
+// every read, i.e. the idle timeout.
+//
+//	p := new(redis.Provider)
+//	aah.App().CacheManager().AddProvider("redis1", p)
+//	session.AddStore("redis", p.SessionStore("session"))
+//
+//	// security.session.store.type = "redis"
+type SessionStore struct {
+	p         *Provider
+	cacheName string
+	c         cache.Cache
+	timeout   time.Duration
+}
+
+// SessionStore method returns the session store backed by the cache of given
+// name, the cache is created on session store Init. So the cache provider
+// must be initialized before the security.
+func (p *Provider) SessionStore(cacheName string) *SessionStore {
+	return &SessionStore{p: p, cacheName: cacheName}
+}
+
+// Init method creates the session cache on the provider.
+func (s *SessionStore) Init(appCfg *config.Config) error {
+	if s.p.appCfg == nil {
+		return fmt.Errorf("aah/cache/%s: session store provider is not initialized", s.cacheName)
+	}
+	s.timeout = parseDuration(appCfg.StringDefault("security.session.timeout", "0m"), "0m")
+	if s.timeout <= 0 {
+		s.timeout = parseDuration(appCfg.StringDefault("security.session.store.redis.timeout", "24h"), "24h")
+	}
+	mode := cache.EvictionModeTTL
+	if appCfg.BoolDefault("security.session.store.redis.slide", false) {
+		mode = cache.EvictionModeSlide
+	}
+	c, err := s.p.Create(&cache.Config{Name: s.cacheName, ProviderName: s.p.name, EvictionMode: mode})
+	if err != nil {
+		return err
+	}
+	s.c = c
+	return nil
+}
+
+// Read method returns the session value for given id, it returns empty
+// string if the session does not exists.
+func (s *SessionStore) Read(id string) string {
+	v, _ := s.c.Get(id).(string)
+	return v
+}
+
+// Save method stores the session value for given id with session timeout.
+func (s *SessionStore) Save(id, value string) error {
+	return s.c.Put(id, value, s.timeout)
+}
+
+// Delete method deletes the session for given id.
+func (s *SessionStore) Delete(id string) error {
+	return s.c.Delete(id)
+}
+
+// IsExists method returns true if the session exists for given id.
+func (s *SessionStore) IsExists(id string) bool {
+	return s.c.Exists(id)
+}
+
+// Cleanup method is no-op, expired sessions are removed by Redis.
+func (s *SessionStore) Cleanup(_ *session.Manager) {}
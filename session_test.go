@@ -0,0 +1,67 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisSessionStore(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	s := p.SessionStore("sessioncache")
+	cfg, _ := config.ParseString(`security {
+		session {
+			timeout = "2s"
+			store {
+				redis {
+					slide = true
+				}
+			}
+		}
+	}`)
+	assert.Nil(t, s.Init(cfg))
+	assert.Equal(t, 2*time.Second, s.timeout)
+	assert.Equal(t, cache.EvictionModeSlide, s.c.(*redisCache).cfg.EvictionMode)
+
+	assert.Equal(t, "", s.Read("session1"))
+	assert.False(t, s.IsExists("session1"))
+	assert.Nil(t, s.Save("session1", "encoded-session-value"))
+	assert.True(t, s.IsExists("session1"))
+	assert.Equal(t, "encoded-session-value", s.Read("session1"))
+
+	// read extends the expiry
+	time.Sleep(1500 * time.Millisecond)
+	assert.Equal(t, "encoded-session-value", s.Read("session1"))
+	time.Sleep(1500 * time.Millisecond)
+	assert.Equal(t, "encoded-session-value", s.Read("session1"))
+
+	assert.Nil(t, s.Delete("session1"))
+	assert.False(t, s.IsExists("session1"))
+
+	// browser session timeout
+	s = p.SessionStore("sessioncache2")
+	cfg, _ = config.ParseString(`security {
+		session {
+			timeout = "0m"
+		}
+	}`)
+	assert.Nil(t, s.Init(cfg))
+	assert.Equal(t, 24*time.Hour, s.timeout)
+
+	assert.NotNil(t, new(Provider).SessionStore("sessioncache").Init(cfg))
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/go-redis/redis"
+)
+
+// Size method returns the number of cache entries. If the cache has its own
+// Redis DB and Flush is allowed to use FLUSHDB, it's Redis DBSIZE otherwise
+// the keys with cache key prefix are counted using SCAN, so that Size counts
+// the keys Flush deletes.
+func (r *redisCache) Size() (int64, error) {
+	var n int64
+	var err error
+	if r.flushesDB() {
+		err = r.forEachShard(func(c Commander) error {
+			size, err := c.DBSize().Result()
+			atomic.AddInt64(&n, size)
+			return err
+		})
+	} else {
+		err = r.scanKeys(escapeGlob(r.entryPrefix())+"*", func(_ Commander, keys []string) error {
+			atomic.AddInt64(&n, int64(len(keys)))
+			return nil
+		})
+	}
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		return 0, fmt.Errorf("aah/cache/%s: size %v", r.Name(), err)
+	}
+	return n, nil
+}
+
+// MemoryUsage method returns the number of bytes used by the cache entry in
+// Redis, including the key and Redis overhead, using Redis MEMORY USAGE. It
+// returns `ErrCacheMiss` if the entry does not exists.
+func (r *redisCache) MemoryUsage(k string) (int64, error) {
+	n, err := r.client().MemoryUsage(r.key(k)).Result()
+	r.p.done(notacacheMiss(err))
+	if err != nil {
+		if notacacheMiss(err) == nil {
+			return 0, ErrCacheMiss
+		}
+		r.stats.error()
+		return 0, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	return n, nil
+}
+
+// TotalMemoryUsage method returns the number of bytes used by all the cache
+// entries in Redis, it scans the cache keys and pipelines MEMORY USAGE per
+// batch of keys.
+func (r *redisCache) TotalMemoryUsage() (int64, error) {
+	var total int64
+	err := r.scanKeys(escapeGlob(r.entryPrefix())+"*", func(c Commander, keys []string) error {
+		cmds := make([]*redis.IntCmd, len(keys))
+		_, err := c.Pipelined(func(pipe redis.Pipeliner) error {
+			for i, k := range keys {
+				cmds[i] = pipe.MemoryUsage(k)
+			}
+			return nil
+		})
+		if err = notacacheMiss(err); err != nil {
+			return err
+		}
+		for _, cmd := range cmds {
+			// entry is expired or deleted since scan reports nil
+			atomic.AddInt64(&total, cmd.Val())
+		}
+		return nil
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		return 0, fmt.Errorf("aah/cache/%s: memory usage %v", r.Name(), err)
+	}
+	return total, nil
+}
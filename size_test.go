@@ -0,0 +1,96 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisSizeAndMemoryUsage(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "sizecache", ProviderName: "redis1"}).(Cache)
+	assert.Nil(t, c.Flush())
+
+	n, err := c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	for i := 0; i < 15; i++ {
+		assert.Nil(t, c.Put(fmt.Sprintf("key%d", i), i, 10*time.Second))
+	}
+	n, err = c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(15), n)
+
+	m, err := c.MemoryUsage("key1")
+	assert.Nil(t, err)
+	assert.True(t, m > 0)
+
+	_, err = c.MemoryUsage("notexists")
+	assert.Equal(t, ErrCacheMiss, err)
+
+	total, err := c.TotalMemoryUsage()
+	assert.Nil(t, err)
+	assert.True(t, total >= 15*m/2)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisSizeOwnDB(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				sizecache {
+					db = 4
+				}
+				sizedbcache {
+					db = 7
+					allow_flushdb = true
+				}
+			}
+		}
+	}
+`)
+	for _, name := range []string{"sizecache", "sizedbcache"} {
+		assert.Nil(t, mgr.CreateCache(&cache.Config{Name: name, ProviderName: "redis1"}))
+	}
+	c := mgr.Cache("sizecache").(*redisCache)
+	dc := mgr.Cache("sizedbcache").(*redisCache)
+	assert.Nil(t, dc.Flush())
+
+	// key of other application sharing the DB
+	assert.Nil(t, c.client().Set("foreign-key1", "value", 10*time.Second).Err())
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+
+	// without allow_flushdb Size counts the keys Flush deletes
+	n, err := c.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// with allow_flushdb Size counts the DB keys Flush deletes
+	assert.Nil(t, dc.client().Set("foreign-key1", "value", 10*time.Second).Err())
+	assert.Nil(t, dc.Put("key1", "value1", 10*time.Second))
+	n, err = dc.Size()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+
+	assert.Nil(t, c.client().Del("foreign-key1").Err())
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, dc.Flush())
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"github.com/go-redis/redis"
+)
+
+// slideGetScript gets the entry and resets its expiration in one round trip,
+// the expiration duration (nanoseconds) is read from the entry header. The
+// expiration is reset only when the remaining time to live is less than
+// ARGV[1] percent of the duration and at least ARGV[2] milliseconds elapsed
+// since the last reset, i.e. the duration minus the remaining time to live.
+var slideGetScript = redis.NewScript(`local v = redis.call("get", KEYS[1])
+if not v or string.byte(v, 1) ~= 0 then
+	return v
+end
+local d = tonumber(string.match(v, "^..(%d+):"))
+if not d then
+	return v
+end
+local ms = math.floor(d / 1000000)
+if ms > 0 then
+	local pttl = redis.call("pttl", KEYS[1])
+	if pttl >= 0 and pttl < ms * tonumber(ARGV[1]) / 100 and ms - pttl >= tonumber(ARGV[2]) then
+		redis.call("pexpire", KEYS[1], ms)
+	end
+end
+return v`)
+
+// getSlide method returns the entry bytes for the slide eviction mode cache,
+// the entry expiration is reset on the server. Entry stored by earlier
+// versions has no header, its expiration is reset by the caller.
+func (r *redisCache) getSlide(k string) ([]byte, error) {
+	s, err := slideGetScript.Run(r.client(), []string{r.key(k)}, r.slideThreshold, durationMillis(r.slideInterval)).String()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisSlideRefreshThreshold(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				slidecache {
+					slide.refresh_threshold = 50
+				}
+			}
+		}
+	}
+`, &cache.Config{Name: "slidecache", ProviderName: "redis1", EvictionMode: cache.EvictionModeSlide}).(Cache)
+
+	assert.Nil(t, c.Put("key1", "value1", 4*time.Second))
+	time.Sleep(1100 * time.Millisecond)
+
+	// more than half of the duration remains, not refreshed
+	assert.Equal(t, "value1", c.Get("key1"))
+	d, err := c.TTL("key1")
+	assert.Nil(t, err)
+	assert.True(t, d <= 3*time.Second)
+
+	time.Sleep(1100 * time.Millisecond)
+	assert.Equal(t, "value1", c.Get("key1"))
+	d, err = c.TTL("key1")
+	assert.Nil(t, err)
+	assert.True(t, d > 3*time.Second)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisSlideRefreshInterval(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				slidecache {
+					slide.refresh_interval = "2s"
+				}
+			}
+		}
+	}
+`, &cache.Config{Name: "slidecache", ProviderName: "redis1", EvictionMode: cache.EvictionModeSlide}).(Cache)
+
+	assert.Nil(t, c.Put("key1", "value1", 4*time.Second))
+	time.Sleep(1100 * time.Millisecond)
+
+	// refreshed less than interval ago, not refreshed
+	assert.Equal(t, "value1", c.Get("key1"))
+	d, err := c.TTL("key1")
+	assert.Nil(t, err)
+	assert.True(t, d <= 3*time.Second)
+
+	time.Sleep(1100 * time.Millisecond)
+	assert.Equal(t, "value1", c.Get("key1"))
+	d, err = c.TTL("key1")
+	assert.Nil(t, err)
+	assert.True(t, d > 3*time.Second)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisSlideEntryWithoutHeader(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "slidecache", ProviderName: "redis1", EvictionMode: cache.EvictionModeSlide}).(Cache)
+
+	// entry stored by earlier versions
+	buf := new(bytes.Buffer)
+	assert.Nil(t, gob.NewEncoder(buf).Encode(&entry{D: 4 * time.Second, V: "value1"}))
+	assert.Nil(t, c.(*redisCache).client().Set("slidecache-key1", buf.Bytes(), 2*time.Second).Err())
+
+	assert.Equal(t, "value1", c.Get("key1"))
+	d, err := c.TTL("key1")
+	assert.Nil(t, err)
+	assert.True(t, d > 3*time.Second)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisSlideInvalidThreshold(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			slide.refresh_threshold = 120
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "slidecache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/slidecache: slide.refresh_threshold must be between 1 and 100", err.Error())
+}
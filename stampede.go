@@ -0,0 +1,101 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// stampede struct protects the cache from stampede of concurrent misses on the
+// same key. Within the process concurrent calls are coalesced into one call
+// using `singleflight`, across the processes optionally a short lived Redis
+// lock `<lock_prefix>stampede:<key>` makes sure only one instance stores the
+// entry while others wait for it. Lock is kept out of the cache key prefix, so
+// it's not counted or deleted as the cache entry.
+//
+// It's enabled per cache via config `stampede_protection = true` and the
+// distributed lock via `stampede_lock_ttl = "3s"`.
+type stampede struct {
+	group   singleflight.Group
+	lockTTL time.Duration
+}
+
+// loadFunc type produces the cache value and its expiration for the key.
+type loadFunc func() (interface{}, time.Duration, error)
+
+// getOrPutProtected method returns the cached entry or loads and puts it with
+// the stampede protection. It reports true if the entry is stored by this
+// call, coalesced calls report false.
+func (r *redisCache) getOrPutProtected(ctx context.Context, k string, load loadFunc) (interface{}, bool, error) {
+	var stored bool
+	ev, err, _ := r.sp.group.Do(k, func() (interface{}, error) {
+		if ev := r.get(ctx, k); ev != nil {
+			return ev, nil
+		}
+		var v interface{}
+		var err error
+		if r.sp.lockTTL > 0 {
+			v, stored, err = r.putWithLock(ctx, k, load)
+		} else {
+			v, err = r.loadAndPut(ctx, k, load)
+			stored = err == nil && v != nil
+		}
+		return v, err
+	})
+	return ev, stored, err
+}
+
+// putWithLock method acquires the distributed lock for the key and puts the
+// entry. If the lock is held by other instance, it waits for the entry till
+// the lock expires and then puts the entry by itself. It reports true if the
+// entry is stored by this instance.
+func (r *redisCache) putWithLock(ctx context.Context, k string, load loadFunc) (interface{}, bool, error) {
+	l, err := r.p.acquireLock(r.p.lockPrefix+"stampede:"+r.key(k), r.sp.lockTTL)
+	switch err {
+	case nil:
+		defer func() {
+			if err := l.Unlock(); err != nil {
+				r.logFor(opGet, k).errorf("aah/cache/%s: key(%s) stampede unlock %v", r.Name(), k, err)
+			}
+		}()
+	case ErrLockNotAcquired:
+		deadline := time.Now().Add(r.sp.lockTTL)
+		for time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+			if ev := r.get(ctx, k); ev != nil {
+				return ev, false, nil
+			}
+		}
+	default:
+		r.logFor(opGet, k).errorf("aah/cache/%s: key(%s) stampede %v", r.Name(), k, err)
+	}
+
+	v, err := r.loadAndPut(ctx, k, load)
+	return v, err == nil && v != nil, err
+}
+
+// loadAndPut method produces the value and puts it into cache store. Nil value
+// is stored as `NotFound` if the negative caching is enabled, otherwise it's
+// not stored.
+func (r *redisCache) loadAndPut(ctx context.Context, k string, load loadFunc) (interface{}, error) {
+	start := time.Now()
+	v, d, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		if r.negativeTTL <= 0 {
+			return nil, nil
+		}
+		v, d = NotFound, r.negativeTTL
+	}
+	if err = r.put(k, &entry{D: d, V: v, C: time.Since(start), ctx: ctx}); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
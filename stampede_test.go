@@ -0,0 +1,63 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStampedeProtection(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			stampede_protection = true
+			stampede_lock_ttl = "2s"
+		}
+	}
+`, &cache.Config{Name: "stampedecache", ProviderName: "redis1"})
+	r := c.(*redisCache)
+	assert.NotNil(t, r.sp)
+	assert.Equal(t, 2*time.Second, r.sp.lockTTL)
+
+	var wg sync.WaitGroup
+	var stored int32
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, ok, err := r.GetOrPutE("stampede-key1", "value1", 10*time.Second)
+			assert.Nil(t, err)
+			assert.Equal(t, "value1", v)
+			if ok {
+				atomic.AddInt32(&stored, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.True(t, r.Stats().Puts < 50)
+	assert.Equal(t, int32(r.Stats().Puts), atomic.LoadInt32(&stored))
+	assert.Equal(t, int64(0), r.p.client().Exists("lock-stampede:stampedecache-stampede-key1").Val())
+
+	// lock held by other instance, entry gets stored after the lock wait
+	assert.Nil(t, r.p.client().SetNX("lock-stampede:stampedecache-stampede-key2", "other", 200*time.Millisecond).Err())
+	// lock is not counted as the cache entry
+	n, err := r.FlushDryRun()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+	v, err := c.GetOrPut("stampede-key2", "value2", 10*time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "value2", v)
+
+	assert.Nil(t, c.Flush())
+}
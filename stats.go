@@ -0,0 +1,55 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import "sync/atomic"
+
+// Stats struct holds the operation statistics of the cache instance.
+type Stats struct {
+	Hits    uint64
+	Misses  uint64
+	Puts    uint64
+	Deletes uint64
+	Errors  uint64
+	Latency LatencyStats
+}
+
+// HitRatio method returns the ratio of hits against total lookups.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// cacheStats struct holds the counters of the cache instance, values are
+// updated atomically. Keep it as the first field of the struct to guarantee
+// 64-bit alignment for atomic operations.
+type cacheStats struct {
+	hits    uint64
+	misses  uint64
+	puts    uint64
+	deletes uint64
+	errors  uint64
+	latency cacheLatency
+}
+
+func (s *cacheStats) hit()    { atomic.AddUint64(&s.hits, 1) }
+func (s *cacheStats) miss()   { atomic.AddUint64(&s.misses, 1) }
+func (s *cacheStats) put()    { atomic.AddUint64(&s.puts, 1) }
+func (s *cacheStats) delete() { atomic.AddUint64(&s.deletes, 1) }
+func (s *cacheStats) error()  { atomic.AddUint64(&s.errors, 1) }
+
+func (s *cacheStats) snapshot() Stats {
+	return Stats{
+		Hits:    atomic.LoadUint64(&s.hits),
+		Misses:  atomic.LoadUint64(&s.misses),
+		Puts:    atomic.LoadUint64(&s.puts),
+		Deletes: atomic.LoadUint64(&s.deletes),
+		Errors:  atomic.LoadUint64(&s.errors),
+		Latency: s.latency.snapshot(),
+	}
+}
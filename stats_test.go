@@ -0,0 +1,47 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisCacheStats(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "statscache", ProviderName: "redis1"}).(Cache)
+
+	assert.Equal(t, Stats{}, c.Stats())
+	assert.Equal(t, float64(0), c.Stats().HitRatio())
+
+	assert.Nil(t, c.Put("stats-key1", "value1", 3*time.Second))
+	assert.Nil(t, c.Put("stats-key2", "value2", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("stats-key1"))
+	assert.Nil(t, c.Get("stats-key3"))
+	assert.Nil(t, c.Delete("stats-key2"))
+
+	s := c.Stats()
+	assert.Equal(t, uint64(1), s.Hits)
+	assert.Equal(t, uint64(1), s.Misses)
+	assert.Equal(t, uint64(2), s.Puts)
+	assert.Equal(t, uint64(1), s.Deletes)
+	assert.Equal(t, uint64(0), s.Errors)
+	assert.Equal(t, 0.5, s.HitRatio())
+
+	err := c.Put("stats-key4", struct{ Name string }{Name: "unregistered"}, 3*time.Second)
+	assert.NotNil(t, err)
+	assert.Equal(t, uint64(1), c.Stats().Errors)
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// swapScript sets the entry and returns the previous one atomically, it's
+// `SET ... GET` with expiration that works with Redis prior to 6.2.
+var swapScript = redis.NewScript(`local v = redis.call("get", KEYS[1])
+if tonumber(ARGV[2]) > 0 then
+	redis.call("set", KEYS[1], ARGV[1], "px", ARGV[2])
+else
+	redis.call("set", KEYS[1], ARGV[1])
+end
+return v`)
+
+// Swap method atomically replaces the cache entry with specified expiration
+// and returns the previous value. Previous value is nil if the entry does not
+// exists. Queued write-behind entries of the key are cancelled.
+func (r *redisCache) Swap(k string, v interface{}, d time.Duration) (interface{}, error) {
+	start := r.begin(opPut, k)
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opPut, k, ErrCircuitOpen, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	if r.wb != nil {
+		r.wb.cancel(k)
+	}
+	registerType(reflect.TypeOf(v))
+	d = r.expiration(k, d)
+	e := &entry{D: d, V: v}
+	if d > 0 {
+		e.E = start.Add(d)
+	}
+
+	buf := acquireBuffer()
+	if err := r.encode(k, buf, e); err != nil {
+		releaseBuffer(buf)
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	old, err := swapScript.Run(r.client(), []string{r.key(k)}, buf.Bytes(), durationMillis(d)).String()
+	releaseBuffer(buf)
+	r.p.done(notacacheMiss(err))
+	if err = notacacheMiss(err); err != nil {
+		r.stats.error()
+		r.observeError(opPut, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	if r.local != nil {
+		r.local.Put(k, v, d)
+	}
+	if r.inv != nil {
+		r.inv.publish(k)
+	}
+	r.emit(opPut, k)
+	r.metaPut(k, d)
+	r.stats.put()
+	r.observe(opPut, k, resultOK, start)
+
+	if len(old) == 0 {
+		return nil, nil
+	}
+	var oe entry
+	if err = r.decode(k, []byte(old), &oe); err != nil {
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	return oe.V, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisSwap(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "swapcache", ProviderName: "redis1"}).(Cache)
+
+	old, err := c.Swap("token", "token1", 10*time.Second)
+	assert.Nil(t, err)
+	assert.Nil(t, old)
+
+	old, err = c.Swap("token", "token2", 20*time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "token1", old)
+	assert.Equal(t, "token2", c.Get("token"))
+
+	d, err := c.TTL("token")
+	assert.Nil(t, err)
+	assert.True(t, d > 19*time.Second)
+
+	// no expiration
+	old, err = c.Swap("token", "token3", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "token2", old)
+	d, err = c.TTL("token")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(-1), d)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestDurationMillis(t *testing.T) {
+	assert.Equal(t, int64(0), durationMillis(0))
+	assert.Equal(t, int64(1), durationMillis(time.Microsecond))
+	assert.Equal(t, int64(1500), durationMillis(1500*time.Millisecond))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-redis/redis"
+)
+
+// takeScript gets and deletes the entry atomically, it's `GETDEL` that works
+// with Redis prior to 6.2.
+var takeScript = redis.NewScript(`local v = redis.call("get", KEYS[1])
+if v then
+	redis.call("del", KEYS[1])
+end
+return v`)
+
+// Take method atomically returns and deletes the cache entry, so that only
+// one caller gets the value, e.g. one-time tokens, nonces and claim checks. It
+// uses Redis `GETDEL` and falls back to Lua script on Redis prior to 6.2.
+// Queued write-behind entries of the key are cancelled. It returns
+// `ErrCacheMiss` if the entry does not exists.
+func (r *redisCache) Take(k string) (interface{}, error) {
+	start := r.begin(opGet, k)
+	if r.local != nil {
+		r.local.Delete(k)
+	}
+	if r.circuitOpen() {
+		r.stats.error()
+		r.observeError(opGet, k, ErrCircuitOpen, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, ErrCircuitOpen)
+	}
+	if r.wb != nil {
+		r.wb.cancel(k)
+	}
+
+	b, err := r.getDel(r.key(k))
+	r.p.done(notacacheMiss(err))
+	if err != nil {
+		r.stats.miss()
+		if err = notacacheMiss(err); err == nil {
+			r.observe(opGet, k, resultMiss, start)
+			return nil, ErrCacheMiss
+		}
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	if r.inv != nil {
+		r.inv.publish(k)
+	}
+	r.emit(opDelete, k)
+	r.metaDelete(k)
+	r.stats.hit()
+	r.stats.delete()
+
+	var e entry
+	if err = r.decode(k, b, &e); err != nil {
+		r.stats.error()
+		r.observeError(opGet, k, err, start)
+		return nil, fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	r.observe(opGet, k, resultHit, start)
+	if e.V == NotFound {
+		return nil, ErrNotFound
+	}
+	return e.V, nil
+}
+
+// getDel method gets and deletes the Redis key using `GETDEL`, once Redis
+// reports the command as unknown the Lua script is used thereafter.
+func (r *redisCache) getDel(key string) ([]byte, error) {
+	if atomic.LoadInt32(&r.p.noGetDel) == 0 {
+		cmd := redis.NewStringCmd("getdel", key)
+		_ = r.client().Process(cmd)
+		v, err := cmd.Bytes()
+		if err == nil || !strings.HasPrefix(strings.ToLower(err.Error()), "err unknown command") {
+			return v, err
+		}
+		atomic.StoreInt32(&r.p.noGetDel, 1)
+	}
+	v, err := takeScript.Run(r.client(), []string{key}).String()
+	return []byte(v), err
+}
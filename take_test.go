@@ -0,0 +1,58 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisTake(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "takecache", ProviderName: "redis1"}).(Cache)
+
+	for _, fallback := range []int32{0, 1} {
+		atomic.StoreInt32(&c.(*redisCache).p.noGetDel, fallback)
+
+		assert.Nil(t, c.Put("nonce-1", "token1", time.Minute))
+		v, err := c.Take("nonce-1")
+		assert.Nil(t, err)
+		assert.Equal(t, "token1", v)
+		assert.False(t, c.Exists("nonce-1"))
+
+		v, err = c.Take("nonce-1")
+		assert.Nil(t, v)
+		assert.Equal(t, ErrCacheMiss, err)
+
+		// only one concurrent caller takes the entry
+		assert.Nil(t, c.Put("nonce-2", map[string]interface{}{"id": 2}, time.Minute))
+		var taken int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := c.Take("nonce-2"); err == nil {
+					atomic.AddInt32(&taken, 1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&taken))
+	}
+
+	assert.Nil(t, c.Flush())
+}
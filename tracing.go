@@ -0,0 +1,88 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "aahframe.work/cache/provider/redis"
+
+// SetTracerProvider method enables OpenTelemetry tracing of cache operations
+// using given tracer provider. Each Get, Put, Delete, Exists, TTL and Flush
+// operation is recorded as a span with cache name, operation, key hash and
+// hit/miss attributes. Passing nil disables the tracing.
+//
+//	c.(redis.Cache).WithContext(req.Context()).Get("key1")
+func (p *Provider) SetTracerProvider(tp trace.TracerProvider) {
+	if tp == nil {
+		p.tracer = nil
+		return
+	}
+	p.tracer = tp.Tracer(tracerName)
+}
+
+// opStart struct is the start of the cache operation, it carries the span of
+// the operation if the tracing is enabled.
+type opStart struct {
+	time.Time
+	span trace.Span
+}
+
+// startSpan method starts the span of the cache operation before the Redis
+// command is sent, as a child of the span carried by ctx. Cache interface
+// methods do not carry `context.Context`, use the view returned by
+// `WithContext` to parent the spans to the caller's span.
+func (p *Provider) startSpan(ctx context.Context, cacheName, op, k string) trace.Span {
+	if p.tracer == nil {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", "redis"),
+		attribute.String("cache.name", cacheName),
+		attribute.String("cache.operation", op),
+	}
+	if len(k) > 0 {
+		attrs = append(attrs, attribute.String("cache.key_hash", keyHash(k)))
+	}
+	_, span := p.tracer.Start(ctx, "cache."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	return span
+}
+
+// endSpan method ends the span of the completed cache operation with its
+// result.
+func endSpan(span trace.Span, op, result string) {
+	if span == nil {
+		return
+	}
+	if op == opGet && result != resultError {
+		span.SetAttributes(attribute.Bool("cache.hit", result == resultHit))
+	}
+	if result == resultError {
+		span.SetStatus(codes.Error, "cache operation failed")
+	}
+	span.End()
+}
+
+// keyHash method returns the FNV-1a hash of cache key, so that span attributes
+// do not carry the application data.
+func keyHash(k string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(k))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRedisTracing(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	tp := &testTracerProvider{TracerProvider: trace.NewNoopTracerProvider()}
+	p := mgr.Provider("redis1").(*Provider)
+	p.SetTracerProvider(tp)
+
+	err := mgr.CreateCache(&cache.Config{Name: "tracecache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("tracecache")
+
+	assert.Nil(t, c.Put("trace-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", c.Get("trace-key1"))
+	assert.True(t, c.Exists("trace-key1"))
+	assert.Nil(t, c.Delete("trace-key1"))
+	assert.Nil(t, c.Flush())
+	assert.Equal(t, []string{"cache.put", "cache.get", "cache.exists", "cache.delete", "cache.flush"}, tp.spans)
+
+	p.SetTracerProvider(nil)
+	assert.Nil(t, c.Get("trace-key1"))
+	assert.Len(t, tp.spans, 5)
+}
+
+func TestRedisTracingParentContext(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	tp := &testTracerProvider{TracerProvider: trace.NewNoopTracerProvider()}
+	p := mgr.Provider("redis1").(*Provider)
+	p.SetTracerProvider(tp)
+	p.AddHook(tp)
+
+	err := mgr.CreateCache(&cache.Config{Name: "traceparentcache", ProviderName: "redis1"})
+	assert.Nil(t, err, "unable to create cache")
+	c := mgr.Cache("traceparentcache").(*redisCache)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ac := c.WithContext(trace.ContextWithSpanContext(context.Background(), parent))
+	assert.Nil(t, ac.Put("trace-parent-key1", "value1", 3*time.Second))
+	assert.Equal(t, "value1", ac.Get("trace-parent-key1"))
+	assert.True(t, ac.Exists("trace-parent-key1"))
+	assert.Nil(t, ac.Delete("trace-parent-key1"))
+	assert.Nil(t, ac.Flush())
+
+	// span is started before the operation
+	assert.Equal(t, []string{"cache.put", "before.put", "cache.get", "before.get",
+		"cache.exists", "before.exists", "cache.delete", "before.delete",
+		"cache.flush", "before.flush"}, tp.spans)
+	assert.Len(t, tp.parents, 5)
+	for _, sc := range tp.parents {
+		assert.Equal(t, parent, sc)
+	}
+
+	// operations on the cache itself are root spans
+	tp.parents = nil
+	assert.Nil(t, c.Get("trace-parent-key1"))
+	assert.False(t, tp.parents[0].IsValid())
+}
+
+func TestKeyHash(t *testing.T) {
+	assert.Equal(t, keyHash("key1"), keyHash("key1"))
+	assert.NotEqual(t, keyHash("key1"), keyHash("key2"))
+}
+
+type testTracerProvider struct {
+	trace.TracerProvider
+	mu      sync.Mutex
+	spans   []string
+	parents []trace.SpanContext
+}
+
+func (tp *testTracerProvider) BeforeOp(cacheName, op, key string) {
+	tp.mu.Lock()
+	tp.spans = append(tp.spans, "before."+op)
+	tp.mu.Unlock()
+}
+
+func (tp *testTracerProvider) AfterOp(cacheName, op, key string, d time.Duration, err error) {}
+
+func (tp *testTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return &testTracer{Tracer: tp.TracerProvider.Tracer(name, opts...), tp: tp}
+}
+
+type testTracer struct {
+	trace.Tracer
+	tp *testTracerProvider
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.tp.mu.Lock()
+	t.tp.spans = append(t.tp.spans, name)
+	t.tp.parents = append(t.tp.parents, trace.SpanContextFromContext(ctx))
+	t.tp.mu.Unlock()
+	return t.Tracer.Start(ctx, name, opts...)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ttlPolicy struct holds the cache entry expiration policy configured via
+// `ttl.default`, `ttl.min`, `ttl.max` and `ttl.jitter`. Zero value means not
+// configured.
+type ttlPolicy struct {
+	def    time.Duration
+	min    time.Duration
+	max    time.Duration
+	jitter float64
+}
+
+func (p *Provider) newTTLPolicy(cacheName string) (ttlPolicy, error) {
+	tp := ttlPolicy{
+		def: parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "ttl.default"), "0s"), "0s"),
+		min: parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "ttl.min"), "0s"), "0s"),
+		max: parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "ttl.max"), "0s"), "0s"),
+	}
+	if tp.min > 0 && tp.max > 0 && tp.min > tp.max {
+		return tp, fmt.Errorf("ttl.min(%s) is greater than ttl.max(%s)", tp.min, tp.max)
+	}
+	jitter := strings.TrimSuffix(strings.TrimSpace(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "ttl.jitter"), "0")), "%")
+	pct, err := strconv.ParseFloat(jitter, 64)
+	if err != nil || pct < 0 || pct >= 100 {
+		return tp, fmt.Errorf("ttl.jitter(%s) must be a percentage between 0 and 100", jitter)
+	}
+	tp.jitter = pct / 100
+	return tp, nil
+}
+
+// expiration method returns the cache entry expiration for given duration.
+// Zero duration gets the `ttl.default`, out of range duration is clamped to
+// `ttl.min` or `ttl.max` with warning, no expiration is clamped to `ttl.max`.
+// Expiration is randomized within `ttl.jitter` percent band, so that the
+// entries written at the same moment do not expire simultaneously.
+func (r *redisCache) expiration(k string, d time.Duration) time.Duration {
+	if d <= 0 && r.ttl.def > 0 {
+		d = r.ttl.def
+	}
+	switch {
+	case r.ttl.max > 0 && (d <= 0 || d > r.ttl.max):
+		r.logFor(opPut, k).warnf("aah/cache/%s: key(%s) expiration %s is clamped to ttl.max(%s)", r.Name(), k, d, r.ttl.max)
+		d = r.ttl.max
+	case d > 0 && d < r.ttl.min:
+		r.logFor(opPut, k).warnf("aah/cache/%s: key(%s) expiration %s is clamped to ttl.min(%s)", r.Name(), k, d, r.ttl.min)
+		d = r.ttl.min
+	}
+	if d > 0 && r.ttl.jitter > 0 {
+		d += time.Duration(float64(d) * r.ttl.jitter * (2*rand.Float64() - 1))
+		if r.ttl.max > 0 && d > r.ttl.max {
+			d = r.ttl.max
+		}
+		if d < r.ttl.min {
+			d = r.ttl.min
+		}
+	}
+	return d
+}
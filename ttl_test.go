@@ -0,0 +1,165 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisTTLPolicy(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			ttl {
+				default = "30s"
+				min = "5s"
+				max = "1m"
+			}
+		}
+	}
+`, &cache.Config{Name: "ttlcache", ProviderName: "redis1"}).(Cache)
+
+	testcases := []struct {
+		key      string
+		d        time.Duration
+		min, max time.Duration
+	}{
+		{key: "default", d: 0, min: 29 * time.Second, max: 30 * time.Second},
+		{key: "min", d: time.Millisecond, min: 4 * time.Second, max: 5 * time.Second},
+		{key: "max", d: time.Hour, min: 59 * time.Second, max: time.Minute},
+		{key: "inrange", d: 10 * time.Second, min: 9 * time.Second, max: 10 * time.Second},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.key, func(t *testing.T) {
+			assert.Nil(t, c.Put(tc.key, "value", tc.d))
+			d, err := c.TTL(tc.key)
+			assert.Nil(t, err)
+			assert.True(t, d >= tc.min && d <= tc.max, "ttl %s", d)
+		})
+	}
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisTTLPolicyExpiration(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			ttl.max = "1m"
+		}
+	}
+`, &cache.Config{Name: "ttlcache", ProviderName: "redis1"}).(*redisCache)
+
+	// no expiration is clamped to max
+	assert.Equal(t, time.Minute, c.expiration("key1", 0))
+	assert.Equal(t, time.Millisecond, c.expiration("key1", time.Millisecond))
+
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			ttl {
+				min = "1m"
+				max = "1s"
+			}
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "ttlcache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/ttlcache: ttl.min(1m0s) is greater than ttl.max(1s)", err.Error())
+}
+
+func TestRedisTTLJitter(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			ttl.jitter = "10%"
+		}
+	}
+`, &cache.Config{Name: "ttlcache", ProviderName: "redis1"}).(*redisCache)
+	assert.Equal(t, 0.1, c.ttl.jitter)
+
+	distinct := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		d := c.expiration("key1", 100*time.Second)
+		assert.True(t, d >= 90*time.Second && d <= 110*time.Second, "expiration %s", d)
+		distinct[d] = true
+	}
+	assert.True(t, len(distinct) > 1)
+
+	// no expiration is not randomized
+	assert.Equal(t, time.Duration(0), c.expiration("key1", 0))
+
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			ttl.jitter = "120%"
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "ttlcache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/ttlcache: ttl.jitter(120) must be a percentage between 0 and 100", err.Error())
+}
+
+func TestRedisTTLPolicyExtend(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			ttl.max = "1m"
+		}
+	}
+`, &cache.Config{Name: "ttlextendcache", ProviderName: "redis1"}).(Cache)
+
+	assertTTL := func(k string) {
+		d, err := c.TTL(k)
+		assert.Nil(t, err)
+		assert.True(t, d > 59*time.Second && d <= time.Minute, "ttl %s", d)
+	}
+
+	// removing the expiration is clamped to ttl.max
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	assert.Nil(t, c.Expire("key1", 0))
+	assertTTL("key1")
+	assert.Nil(t, c.Expire("key1", time.Hour))
+	assertTTL("key1")
+
+	assert.Nil(t, c.Put("key2", "value2", 10*time.Second))
+	v, err := c.GetAndExtend("key2", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "value2", v)
+	assertTTL("key2")
+
+	// imported entries without expiration are clamped to ttl.max
+	r := c.(*redisCache)
+	assert.Nil(t, c.Put("key3", "value3", 10*time.Second))
+	b, err := r.client().Get(r.key("key3")).Bytes()
+	assert.Nil(t, err)
+	rec, _ := json.Marshal(exportRecord{Key: "key3", Value: b})
+	assert.Nil(t, c.Flush())
+	assert.Nil(t, c.Import(bytes.NewReader(rec)))
+	assert.Equal(t, "value3", c.Get("key3"))
+	assertTTL("key3")
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var registeredTypes sync.Map
+
+// gobTypes are the common container types could be registered via config
+// `gob.types` by its name.
+var gobTypes = map[string]interface{}{
+	"[]interface {}":                []interface{}(nil),
+	"map[string]interface {}":       map[string]interface{}(nil),
+	"map[interface {}]interface {}": map[interface{}]interface{}(nil),
+	"map[string]string":             map[string]string(nil),
+	"map[string]int":                map[string]int(nil),
+	"map[string]int64":              map[string]int64(nil),
+	"map[string]float64":            map[string]float64(nil),
+	"[]string":                      []string(nil),
+	"[]int":                         []int(nil),
+	"[]int64":                       []int64(nil),
+	"[]float64":                     []float64(nil),
+	"time.Time":                     time.Time{},
+}
+
+// GetInto method decodes the cached entry for given key into the value pointed
+// to by dest. It returns `ErrCacheMiss` if the entry does not exists. Type of
+// dest is registered with gob, so the cached value could be decoded without
+// prior `gob.Register` call. Cached `[]byte` or `string` value is JSON decoded
+// into dest if it's not assignable.
+//
+//	var u User
+//	err := c.GetInto("user-1", &u)
+func (r *redisCache) GetInto(k string, dest interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("aah/cache/%s: key(%s) non-nil pointer expected, got %T", r.Name(), k, dest)
+	}
+	registerType(dv.Type().Elem())
+
+	v, err := r.GetE(k)
+	if err != nil {
+		return err
+	}
+	if err := assign(dv.Elem(), v); err != nil {
+		return fmt.Errorf("aah/cache/%s: key(%s) %v", r.Name(), k, err)
+	}
+	return nil
+}
+
+// assign method sets the value v into dest, it dereferences the pointer value
+// and falls back to JSON decode for `[]byte` and `string` values. Nil value
+// sets the zero value of dest.
+func assign(dest reflect.Value, v interface{}) error {
+	vv := reflect.ValueOf(v)
+	if !vv.IsValid() {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+	if vv.Type().AssignableTo(dest.Type()) {
+		dest.Set(vv)
+		return nil
+	}
+	if vv.Kind() == reflect.Ptr && !vv.IsNil() && vv.Elem().Type().AssignableTo(dest.Type()) {
+		dest.Set(vv.Elem())
+		return nil
+	}
+	switch b := v.(type) {
+	case []byte:
+		return json.Unmarshal(b, dest.Addr().Interface())
+	case string:
+		return json.Unmarshal([]byte(b), dest.Addr().Interface())
+	case map[string]interface{}, []interface{}, float64:
+		// generic JSON value of the JSON layout entry
+		jb, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(jb, dest.Addr().Interface())
+	}
+	return fmt.Errorf("cannot assign %T to %s", v, dest.Type())
+}
+
+// RegisterTypes method registers the types of given values with gob, so the
+// values stored as interface, e.g. within `map[string]interface{}`, could be
+// encoded and decoded. Conflicting registrations are ignored. Common container
+// types could be registered via config `gob.types` by its name, e.g.
+// `gob.types = ["map[string]string", "[]string"]`.
+//
+//	p.RegisterTypes(User{}, Address{}, []Order{})
+func (p *Provider) RegisterTypes(values ...interface{}) {
+	for _, v := range values {
+		registerType(reflect.TypeOf(v))
+	}
+}
+
+// registerConfigTypes method registers the types configured via `gob.types`.
+func (p *Provider) registerConfigTypes() error {
+	names, _ := p.appCfg.StringList(p.cfgPrefix + "gob.types")
+	for _, name := range names {
+		v, found := gobTypes[name]
+		if !found {
+			return fmt.Errorf("unsupported gob.types '%s'", name)
+		}
+		p.RegisterTypes(v)
+	}
+	return nil
+}
+
+// gobError method returns the gob error naming the type is not registered,
+// other errors are returned as is.
+func gobError(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	for _, s := range []string{"name not registered for interface: ", "type not registered for interface: "} {
+		if idx := strings.Index(msg, s); idx >= 0 {
+			return fmt.Errorf("gob: type %s is not registered, register it via Provider.RegisterTypes", strings.Trim(msg[idx+len(s):], `"`))
+		}
+	}
+	return err
+}
+
+// registerType method registers the type and its pointer type with gob once.
+// Conflicting registrations are ignored, i.e. the type is registered by
+// the application with different name.
+func registerType(t reflect.Type) {
+	if t == nil || t.Kind() == reflect.Interface {
+		return
+	}
+	if _, loaded := registeredTypes.LoadOrStore(t, true); loaded {
+		return
+	}
+	for _, rt := range []reflect.Type{t, reflect.PtrTo(t)} {
+		func() {
+			defer func() { _ = recover() }()
+			gob.Register(reflect.Zero(rt).Interface())
+		}()
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+// Package typed provides the generic helpers for the Redis cache provider,
+// so that the cached values could be read without type assertions at every
+// call site.
+//
+//	u, err := typed.Get[User](aah.App().CacheManager().Cache("users"), "user-1")
+package typed // import "aahframe.work/cache/provider/redis/typed"
+
+import (
+	"fmt"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/cache/provider/redis"
+)
+
+// Get function returns the cached entry of type T for given key. It returns
+// `redis.ErrCacheMiss` if the entry does not exists. Redis cache decodes the
+// entry directly into T via `GetInto`, other caches values are type asserted.
+func Get[T any](c cache.Cache, k string) (T, error) {
+	var v T
+	if rc, ok := c.(redis.Cache); ok {
+		err := rc.GetInto(k, &v)
+		return v, err
+	}
+	ev := c.Get(k)
+	if ev == nil {
+		return v, redis.ErrCacheMiss
+	}
+	tv, ok := ev.(T)
+	if !ok {
+		return v, fmt.Errorf("aah/cache/%s: key(%s) cannot assign %T to %T", c.Name(), k, ev, v)
+	}
+	return tv, nil
+}
+
+// GetOrPut function returns the cached entry of type T for given key if it
+// exists otherwise it puts the given value into cache store and returns it.
+func GetOrPut[T any](c cache.Cache, k string, v T, d time.Duration) (T, error) {
+	ev, err := Get[T](c, k)
+	if err == nil {
+		return ev, nil
+	}
+	if err != redis.ErrCacheMiss {
+		return ev, err
+	}
+	if err = c.Put(k, v, d); err != nil {
+		return ev, err
+	}
+	return v, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package typed
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"aahframe.work/cache/provider/redis"
+	"aahframe.work/config"
+	"aahframe.work/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func TestTypedGet(t *testing.T) {
+	c := createTestCache(t)
+
+	assert.Nil(t, c.Put("user1", user{ID: 1, Name: "aah"}, 10*time.Second))
+	u, err := Get[user](c, "user1")
+	assert.Nil(t, err)
+	assert.Equal(t, user{ID: 1, Name: "aah"}, u)
+
+	_, err = Get[user](c, "notexists")
+	assert.Equal(t, redis.ErrCacheMiss, err)
+
+	_, err = Get[int](c, "user1")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestTypedGetOrPut(t *testing.T) {
+	c := createTestCache(t)
+
+	v, err := GetOrPut(c, "key1", "value1", 10*time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "value1", v)
+
+	v, err = GetOrPut(c, "key1", "value2", 10*time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "value1", v)
+
+	assert.Nil(t, c.Flush())
+}
+
+func createTestCache(t *testing.T) cache.Cache {
+	mgr := cache.NewManager()
+	mgr.AddProvider("redis1", new(redis.Provider))
+
+	cfg, _ := config.ParseString(`
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`)
+	l, _ := log.New(config.NewEmpty())
+	l.SetWriter(ioutil.Discard)
+	assert.Nil(t, mgr.InitProviders(cfg, l))
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "typedcache", ProviderName: "redis1"}))
+	return mgr.Cache("typedcache")
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type typedUser struct {
+	ID   int
+	Name string
+}
+
+func TestRedisGetInto(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "typedcache", ProviderName: "redis1"}).(Cache)
+
+	assert.Nil(t, c.Put("user1", typedUser{ID: 1, Name: "aah"}, 10*time.Second))
+	var u typedUser
+	assert.Nil(t, c.GetInto("user1", &u))
+	assert.Equal(t, typedUser{ID: 1, Name: "aah"}, u)
+
+	// pointer value is dereferenced
+	assert.Nil(t, c.Put("user2", &typedUser{ID: 2, Name: "cache"}, 10*time.Second))
+	assert.Nil(t, c.GetInto("user2", &u))
+	assert.Equal(t, typedUser{ID: 2, Name: "cache"}, u)
+
+	// JSON value is decoded
+	assert.Nil(t, c.Put("user3", []byte(`{"ID":3,"Name":"json"}`), 10*time.Second))
+	assert.Nil(t, c.GetInto("user3", &u))
+	assert.Equal(t, typedUser{ID: 3, Name: "json"}, u)
+
+	var s string
+	assert.Nil(t, c.Put("str1", "value1", 10*time.Second))
+	assert.Nil(t, c.GetInto("str1", &s))
+	assert.Equal(t, "value1", s)
+
+	assert.Equal(t, ErrCacheMiss, c.GetInto("notexists", &u))
+
+	err := c.GetInto("user1", u)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "non-nil pointer expected")
+
+	var n int
+	err = c.GetInto("user1", &n)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "cannot assign")
+
+	// nil value sets the zero value
+	assert.Nil(t, c.Put("nil1", nil, 10*time.Second))
+	assert.Nil(t, c.GetInto("nil1", &u))
+	assert.Equal(t, typedUser{}, u)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestAssignNil(t *testing.T) {
+	u := &typedUser{ID: 1}
+	var v interface{}
+	assert.Nil(t, assign(reflect.ValueOf(u).Elem(), v))
+	assert.Equal(t, typedUser{}, *u)
+}
+
+type gobOrder struct {
+	ID    int
+	Items []string
+}
+
+func TestRedisRegisterTypes(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			gob.types = ["map[string]string", "[]string"]
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "gobcache", ProviderName: "redis1"}))
+	p := mgr.Provider("redis1").(*Provider)
+	c := mgr.Cache("gobcache")
+
+	// nested value of unregistered type
+	err := c.Put("order1", map[string]interface{}{"order": gobOrder{ID: 1}}, 10*time.Second)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "type redis.gobOrder is not registered, register it via Provider.RegisterTypes")
+
+	p.RegisterTypes(gobOrder{})
+	assert.Nil(t, c.Put("order1", map[string]interface{}{"order": gobOrder{ID: 1, Items: []string{"item1"}}}, 10*time.Second))
+	assert.Equal(t, map[string]interface{}{"order": gobOrder{ID: 1, Items: []string{"item1"}}}, c.Get("order1"))
+
+	// config registered type
+	assert.Nil(t, c.Put("tags", []interface{}{[]string{"a", "b"}}, 10*time.Second))
+	assert.Equal(t, []interface{}{[]string{"a", "b"}}, c.Get("tags"))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestGobError(t *testing.T) {
+	assert.Nil(t, gobError(nil))
+	assert.Equal(t, "gob: type main.User is not registered, register it via Provider.RegisterTypes",
+		gobError(errors.New(`gob: name not registered for interface: "main.User"`)).Error())
+	assert.Equal(t, "gob: type main.User is not registered, register it via Provider.RegisterTypes",
+		gobError(errors.New(`gob: type not registered for interface: main.User`)).Error())
+	assert.Equal(t, "unexpected EOF", gobError(errors.New("unexpected EOF")).Error())
+}
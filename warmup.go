@@ -0,0 +1,180 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// warmupBatchSize is the number of entries written per pipeline on warm-up.
+const warmupBatchSize = 500
+
+// WarmEntry struct is the cache entry value and its expiration for warm-up.
+type WarmEntry struct {
+	Value interface{}
+	TTL   time.Duration
+}
+
+// WarmupFunc func type returns the cache entries to preload on cache create.
+type WarmupFunc func(ctx context.Context) (map[string]WarmEntry, error)
+
+// OnWarmup method registers the warm-up callback for given cache name, it's
+// called when the cache is created. Register it before the cache is created.
+func (p *Provider) OnWarmup(cacheName string, fn WarmupFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.warmups == nil {
+		p.warmups = make(map[string]WarmupFunc)
+	}
+	p.warmups[cacheName] = fn
+}
+
+// Warmup method preloads the given entries into cache store using pipelined
+// writes, existing entries are overwritten. It stops on context cancellation
+// between the batches.
+func (r *redisCache) Warmup(ctx context.Context, entries map[string]WarmEntry) error {
+	if r.circuitOpen() {
+		return fmt.Errorf("aah/cache/%s: warmup %v", r.Name(), ErrCircuitOpen)
+	}
+
+	type warmOp struct {
+		k string
+		b []byte
+		d time.Duration
+	}
+	batch := make([]warmOp, 0, warmupBatchSize)
+	write := func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		_, err := r.client().Pipelined(func(pipe redis.Pipeliner) error {
+			for _, op := range batch {
+				pipe.Set(r.key(op.k), op.b, op.d)
+			}
+			return nil
+		})
+		r.p.done(err)
+		if err != nil {
+			r.stats.error()
+			return err
+		}
+		for range batch {
+			r.stats.put()
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	buf := acquireBuffer()
+	defer releaseBuffer(buf)
+	for k, we := range entries {
+		registerType(reflect.TypeOf(we.Value))
+		e := &entry{D: r.expiration(k, we.TTL), V: we.Value}
+		if e.D > 0 {
+			e.E = time.Now().Add(e.D)
+		}
+		buf.Reset()
+		if err := r.encode(k, buf, e); err != nil {
+			r.stats.error()
+			return fmt.Errorf("aah/cache/%s: key(%s) warmup %v", r.Name(), k, err)
+		}
+		batch = append(batch, warmOp{k: k, b: append([]byte(nil), buf.Bytes()...), d: e.D})
+		if len(batch) == warmupBatchSize {
+			if err := write(); err != nil {
+				return fmt.Errorf("aah/cache/%s: warmup %v", r.Name(), err)
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := write(); err != nil {
+			return fmt.Errorf("aah/cache/%s: warmup %v", r.Name(), err)
+		}
+	}
+	if r.inv != nil {
+		r.inv.publish("")
+	}
+	r.emit(opWarmup, "")
+	return nil
+}
+
+// warmup method preloads the cache on create from the snapshot file configured
+// via `warmup.file` and the registered warm-up callback. Failures are logged,
+// so that the cold cache does not prevent the application start.
+func (r *redisCache) warmup() {
+	p := r.p
+	file := p.appCfg.StringDefault(p.cacheCfgKey(r.Name(), "warmup.file"), "")
+	p.mu.Lock()
+	fn := p.warmups[r.Name()]
+	p.mu.Unlock()
+	if len(file) == 0 && fn == nil {
+		return
+	}
+
+	timeout := parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(r.Name(), "warmup.timeout"), "30s"), "30s")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if len(file) > 0 {
+		entries, err := readWarmupFile(file)
+		if err == nil {
+			err = r.Warmup(ctx, entries)
+		}
+		if err != nil {
+			r.logger.errorf("aah/cache/%s: warmup file '%s' %v", r.Name(), file, err)
+		} else {
+			r.logger.infof("aah/cache/%s: %d entries preloaded from '%s'", r.Name(), len(entries), file)
+		}
+	}
+	if fn != nil {
+		entries, err := fn(ctx)
+		if err == nil {
+			err = r.Warmup(ctx, entries)
+		}
+		if err != nil {
+			r.logger.errorf("aah/cache/%s: warmup %v", r.Name(), err)
+		} else {
+			r.logger.infof("aah/cache/%s: %d entries preloaded", r.Name(), len(entries))
+		}
+	}
+}
+
+// readWarmupFile method reads the warm-up snapshot file. File with extension
+// `.json` is JSON object of `{"key": {"value": <value>, "ttl": "10m"}}`,
+// otherwise it's gob encoded `map[string]WarmEntry`.
+func readWarmupFile(file string) (map[string]WarmEntry, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := make(map[string]WarmEntry)
+	if !strings.EqualFold(filepath.Ext(file), ".json") {
+		err = gob.NewDecoder(f).Decode(&entries)
+		return entries, err
+	}
+
+	var snapshot map[string]struct {
+		Value interface{} `json:"value"`
+		TTL   string      `json:"ttl"`
+	}
+	if err = json.NewDecoder(f).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	for k, v := range snapshot {
+		entries[k] = WarmEntry{Value: v.Value, TTL: parseDuration(v.TTL, "0s")}
+	}
+	return entries, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisWarmup(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "warmcache", ProviderName: "redis1"}).(Cache)
+
+	entries := make(map[string]WarmEntry)
+	for i := 0; i < 600; i++ {
+		entries[fmt.Sprintf("key%d", i)] = WarmEntry{Value: i, TTL: 10 * time.Second}
+	}
+	assert.Nil(t, c.Warmup(context.Background(), entries))
+	assert.Equal(t, 0, c.Get("key0"))
+	assert.Equal(t, 599, c.Get("key599"))
+	assert.Equal(t, uint64(600), c.Stats().Puts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := c.Warmup(ctx, entries)
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/warmcache: warmup context canceled", err.Error())
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisWarmupOnCreate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "warmup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "snapshot.json")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(`{"key1": {"value": "value1", "ttl": "10s"}, "key2": {"value": 2}}`), 0644))
+
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			caches {
+				warmcache {
+					warmup.file = "`+file+`"
+				}
+			}
+		}
+	}
+`)
+	p := mgr.Provider("redis1").(*Provider)
+	p.OnWarmup("warmcache", func(ctx context.Context) (map[string]WarmEntry, error) {
+		return map[string]WarmEntry{"key3": {Value: "value3", TTL: 10 * time.Second}}, nil
+	})
+	p.OnWarmup("othercache", func(ctx context.Context) (map[string]WarmEntry, error) {
+		return nil, errors.New("backend failure")
+	})
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "warmcache", ProviderName: "redis1"}))
+	c := mgr.Cache("warmcache")
+
+	assert.Equal(t, "value1", c.Get("key1"))
+	assert.Equal(t, float64(2), c.Get("key2"))
+	assert.Equal(t, "value3", c.Get("key3"))
+
+	// warm-up failure does not fail the cache create
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "othercache", ProviderName: "redis1"}))
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestReadWarmupFile(t *testing.T) {
+	_, err := readWarmupFile("notexists.json")
+	assert.NotNil(t, err)
+
+	dir, err := ioutil.TempDir("", "warmup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "snapshot.json")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(`{"key1": `), 0644))
+	_, err = readWarmupFile(file)
+	assert.NotNil(t, err)
+}
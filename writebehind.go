@@ -0,0 +1,257 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// Write-behind queue overflow policies.
+const (
+	overflowBlock = "block"
+	overflowDrop  = "drop"
+	overflowSync  = "sync"
+)
+
+// writeBehind struct queues the encoded cache entries on Put and the
+// background workers write them into Redis in batches using pipeline. Batch is
+// flushed when it reaches the batch size or on flush interval.
+//
+// Key is routed to the fixed worker, so the entries of the key are written in
+// the Put order. Only the latest queued entry of the key is written, Delete,
+// Flush, tenant scoped Flush and InvalidateAll cancel the queued entries, so
+// the deleted entries are not written back by the workers.
+//
+// On queue overflow, `block` waits for the queue space, `drop` discards the
+// entry and `sync` writes the entry synchronously.
+type writeBehind struct {
+	r         *redisCache
+	queues    []chan *writeOp
+	batchSize int
+	interval  time.Duration
+	overflow  string
+	mu        sync.RWMutex
+	closed    bool
+	wg        sync.WaitGroup
+
+	// writing is held by the workers while writing the batch and by the
+	// cancellations, so the cancelled entry is not written after the
+	// cancellation returns.
+	writing sync.RWMutex
+	pmu     sync.Mutex
+	seq     uint64
+	pending map[string]uint64
+}
+
+type writeOp struct {
+	k   string
+	rk  string
+	b   []byte
+	d   time.Duration
+	seq uint64
+}
+
+func (p *Provider) newWriteBehind(r *redisCache) (*writeBehind, error) {
+	cacheName := r.Name()
+	wb := &writeBehind{
+		r:         r,
+		batchSize: p.appCfg.IntDefault(p.cacheCfgKey(cacheName, "write_behind.batch_size"), 100),
+		interval:  parseDuration(p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "write_behind.flush_interval"), "100ms"), "100ms"),
+		overflow:  p.appCfg.StringDefault(p.cacheCfgKey(cacheName, "write_behind.overflow"), overflowSync),
+		pending:   make(map[string]uint64),
+	}
+	switch wb.overflow {
+	case overflowBlock, overflowDrop, overflowSync:
+	default:
+		return nil, fmt.Errorf("unsupported overflow policy '%s'", wb.overflow)
+	}
+	workers := p.appCfg.IntDefault(p.cacheCfgKey(cacheName, "write_behind.workers"), 1)
+	if wb.batchSize <= 0 || wb.interval <= 0 || workers <= 0 {
+		return nil, fmt.Errorf("batch_size, flush_interval and workers must be positive")
+	}
+
+	// queue size is shared by the workers
+	size := p.appCfg.IntDefault(p.cacheCfgKey(cacheName, "write_behind.queue_size"), 10000) / workers
+	if size <= 0 {
+		size = 1
+	}
+	wb.queues = make([]chan *writeOp, workers)
+	for i := range wb.queues {
+		wb.queues[i] = make(chan *writeOp, size)
+		wb.wg.Add(1)
+		go wb.worker(wb.queues[i])
+	}
+	return wb, nil
+}
+
+// enqueue method queues the encoded cache entry, it returns false if the entry
+// has to be written synchronously. Redis key of the entry is resolved on
+// enqueue, so the entry queued before InvalidateAll is not written into the
+// new generation.
+func (wb *writeBehind) enqueue(k string, b []byte, d time.Duration) bool {
+	wb.mu.RLock()
+	defer wb.mu.RUnlock()
+	if wb.closed {
+		return false
+	}
+
+	op := &writeOp{k: k, rk: wb.r.key(k), b: append([]byte(nil), b...), d: d}
+	wb.pmu.Lock()
+	wb.seq++
+	op.seq = wb.seq
+	wb.pending[k] = op.seq
+	wb.pmu.Unlock()
+
+	queue := wb.queues[wb.shard(k)]
+	if wb.overflow == overflowBlock {
+		queue <- op
+		return true
+	}
+	select {
+	case queue <- op:
+		return true
+	default:
+	}
+	if wb.overflow == overflowDrop {
+		wb.pmu.Lock()
+		if wb.pending[k] == op.seq {
+			delete(wb.pending, k)
+		}
+		wb.pmu.Unlock()
+		wb.r.stats.error()
+		wb.r.logFor(opPut, k).warnf("aah/cache/%s: key(%s) write-behind queue is full, entry dropped", wb.r.Name(), k)
+		return true
+	}
+	return false
+}
+
+// cancel method cancels the queued entries of given key and waits for the
+// in-flight write of the key, if any.
+func (wb *writeBehind) cancel(k string) {
+	wb.writing.Lock()
+	wb.pmu.Lock()
+	delete(wb.pending, k)
+	wb.pmu.Unlock()
+	wb.writing.Unlock()
+}
+
+// cancelAll method cancels all the queued entries and waits for the in-flight
+// writes.
+func (wb *writeBehind) cancelAll() {
+	wb.writing.Lock()
+	wb.pmu.Lock()
+	wb.pending = make(map[string]uint64)
+	wb.pmu.Unlock()
+	wb.writing.Unlock()
+}
+
+// cancelPrefix method cancels the queued entries of the keys with given
+// prefix and waits for the in-flight writes.
+func (wb *writeBehind) cancelPrefix(prefix string) {
+	wb.writing.Lock()
+	wb.pmu.Lock()
+	for k := range wb.pending {
+		if strings.HasPrefix(k, prefix) {
+			delete(wb.pending, k)
+		}
+	}
+	wb.pmu.Unlock()
+	wb.writing.Unlock()
+}
+
+// shard method returns the worker index of given key.
+func (wb *writeBehind) shard(k string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(k))
+	return int(h.Sum32() % uint32(len(wb.queues)))
+}
+
+func (wb *writeBehind) worker(queue chan *writeOp) {
+	defer wb.wg.Done()
+	ticker := time.NewTicker(wb.interval)
+	defer ticker.Stop()
+
+	batch := make([]*writeOp, 0, wb.batchSize)
+	for {
+		select {
+		case op, ok := <-queue:
+			if !ok {
+				wb.flush(batch)
+				return
+			}
+			if batch = append(batch, op); len(batch) >= wb.batchSize {
+				wb.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			wb.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (wb *writeBehind) flush(batch []*writeOp) {
+	wb.writing.RLock()
+	defer wb.writing.RUnlock()
+	if batch = wb.live(batch); len(batch) == 0 {
+		return
+	}
+	r := wb.r
+	_, err := r.client().Pipelined(func(pipe redis.Pipeliner) error {
+		for _, op := range batch {
+			pipe.Set(op.rk, op.b, op.d)
+		}
+		return nil
+	})
+	r.p.done(err)
+	if err != nil {
+		r.stats.error()
+		r.logger.errorf("aah/cache/%s: write-behind flush of %d entries %v", r.Name(), len(batch), err)
+		return
+	}
+	for _, op := range batch {
+		if r.inv != nil {
+			r.inv.publish(op.k)
+		}
+		r.emit(opPut, op.k)
+	}
+}
+
+// live method returns the entries of the batch which are neither cancelled
+// nor superseded by the later Put of the key, in place.
+func (wb *writeBehind) live(batch []*writeOp) []*writeOp {
+	wb.pmu.Lock()
+	defer wb.pmu.Unlock()
+	ops := batch[:0]
+	for _, op := range batch {
+		if wb.pending[op.k] == op.seq {
+			delete(wb.pending, op.k)
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// close method stops accepting the entries and waits for the queued entries
+// to be written.
+func (wb *writeBehind) close() {
+	wb.mu.Lock()
+	if wb.closed {
+		wb.mu.Unlock()
+		return
+	}
+	wb.closed = true
+	for _, queue := range wb.queues {
+		close(queue)
+	}
+	wb.mu.Unlock()
+	wb.wg.Wait()
+}
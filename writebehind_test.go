@@ -0,0 +1,140 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisWriteBehind(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			write_behind {
+				enable = true
+				batch_size = 10
+				flush_interval = "50ms"
+			}
+		}
+	}
+`)
+	assert.Nil(t, mgr.CreateCache(&cache.Config{Name: "wbcache", ProviderName: "redis1"}))
+	c := mgr.Cache("wbcache")
+
+	for i := 0; i < 25; i++ {
+		assert.Nil(t, c.Put(fmt.Sprintf("key%d", i), i, 10*time.Second))
+	}
+	time.Sleep(200 * time.Millisecond)
+	for i := 0; i < 25; i++ {
+		assert.Equal(t, i, c.Get(fmt.Sprintf("key%d", i)))
+	}
+
+	// queued entries are written on close
+	assert.Nil(t, c.Put("last", "value", 10*time.Second))
+	c.(*redisCache).wb.close()
+	v, err := c.(*redisCache).client().Exists("wbcache-last").Result()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), v)
+
+	// closed queue writes synchronously
+	assert.Nil(t, c.Put("afterclose", "value", 10*time.Second))
+	assert.Equal(t, "value", c.Get("afterclose"))
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisWriteBehindOverflow(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			write_behind {
+				enable = true
+				queue_size = 1
+				flush_interval = "1h"
+				batch_size = 100
+				overflow = "sync"
+			}
+		}
+	}
+`, &cache.Config{Name: "wbcache", ProviderName: "redis1"}).(*redisCache)
+
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	// queue is full, written synchronously
+	assert.Nil(t, c.Put("key2", "value2", 10*time.Second))
+	v, err := c.client().Exists("wbcache-key2").Result()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), v)
+
+	c.wb.close()
+	assert.Equal(t, "value1", c.Get("key1"))
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisWriteBehindInvalidConfig(t *testing.T) {
+	mgr := createCacheMgr(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			write_behind {
+				enable = true
+				overflow = "retry"
+			}
+		}
+	}
+`)
+	err := mgr.CreateCache(&cache.Config{Name: "wbcache", ProviderName: "redis1"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/wbcache: write_behind unsupported overflow policy 'retry'", err.Error())
+}
+
+func TestRedisWriteBehindCancel(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			write_behind {
+				enable = true
+				flush_interval = "1h"
+				batch_size = 1000
+				workers = 4
+			}
+			generation {
+				enable = true
+			}
+		}
+	}
+`, &cache.Config{Name: "wbcache", ProviderName: "redis1"}).(*redisCache)
+
+	// later Put of the key wins regardless of the workers
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, c.Put("key1", i, 10*time.Second))
+	}
+	// queued Put is cancelled by Delete
+	assert.Nil(t, c.Put("key2", "value2", 10*time.Second))
+	assert.Nil(t, c.Delete("key2"))
+	// queued Put is not written into the new generation
+	assert.Nil(t, c.Put("key3", "value3", 10*time.Second))
+	assert.Nil(t, c.InvalidateAll())
+	assert.Nil(t, c.Put("key1", "latest", 10*time.Second))
+
+	c.wb.close()
+	assert.Equal(t, "latest", c.Get("key1"))
+	assert.False(t, c.Exists("key2"))
+	assert.False(t, c.Exists("key3"))
+
+	assert.Nil(t, c.Put("key4", "value4", 10*time.Second))
+	assert.Nil(t, c.Flush())
+	assert.False(t, c.Exists("key4"))
+}
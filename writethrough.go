@@ -0,0 +1,50 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import "fmt"
+
+// WriteThrough func type writes the value for given key into backing store on
+// Put, so that the cache could front a database along with the read-through
+// loader.
+type WriteThrough func(key string, value interface{}) error
+
+// SetWriteThrough method sets the write-through callback of the cache. Set the
+// callback before the cache is being used.
+//
+// By default callback is invoked before the entry is stored into cache and its
+// error is returned by Put without caching the entry. With config
+// `write_through.async = true`, callback is invoked in the background after
+// the entry is stored and its error is logged.
+func (r *redisCache) SetWriteThrough(fn WriteThrough) {
+	r.writeThrough = fn
+}
+
+// writeThroughSync method invokes the write-through callback synchronously if
+// it's configured so.
+func (r *redisCache) writeThroughSync(k string, v interface{}) error {
+	if r.writeThrough == nil || r.writeThroughAsync {
+		return nil
+	}
+	if err := r.writeThrough(k, v); err != nil {
+		r.stats.error()
+		return fmt.Errorf("aah/cache/%s: key(%s) write-through %v", r.Name(), k, err)
+	}
+	return nil
+}
+
+// writeThroughBackground method invokes the write-through callback in the
+// background if it's configured so.
+func (r *redisCache) writeThroughBackground(k string, v interface{}) {
+	if r.writeThrough == nil || !r.writeThroughAsync {
+		return
+	}
+	go func() {
+		if err := r.writeThrough(k, v); err != nil {
+			r.stats.error()
+			r.logFor(opPut, k).errorf("aah/cache/%s: key(%s) write-through %v", r.Name(), k, err)
+		}
+	}()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisWriteThrough(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+		}
+	}
+`, &cache.Config{Name: "wtcache", ProviderName: "redis1"}).(Cache)
+
+	store := make(map[string]interface{})
+	c.SetWriteThrough(func(key string, value interface{}) error {
+		if key == "failure" {
+			return errors.New("database failure")
+		}
+		store[key] = value
+		return nil
+	})
+
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	assert.Equal(t, "value1", store["key1"])
+	assert.Equal(t, "value1", c.Get("key1"))
+
+	// entry is not cached on write-through failure
+	err := c.Put("failure", "value2", 10*time.Second)
+	assert.NotNil(t, err)
+	assert.Equal(t, "aah/cache/wtcache: key(failure) write-through database failure", err.Error())
+	assert.False(t, c.Exists("failure"))
+
+	// loaded entries are not written back
+	c.SetLoader(func(key string) (interface{}, time.Duration, error) {
+		return "loaded", 10 * time.Second, nil
+	})
+	assert.Equal(t, "loaded", c.Get("key2"))
+	_, found := store["key2"]
+	assert.False(t, found)
+
+	assert.Nil(t, c.Flush())
+}
+
+func TestRedisWriteThroughAsync(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			write_through.async = true
+		}
+	}
+`, &cache.Config{Name: "wtcache", ProviderName: "redis1"}).(Cache)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	var mu sync.Mutex
+	store := make(map[string]interface{})
+	c.SetWriteThrough(func(key string, value interface{}) error {
+		defer wg.Done()
+		if key == "failure" {
+			return errors.New("database failure")
+		}
+		mu.Lock()
+		store[key] = value
+		mu.Unlock()
+		return nil
+	})
+
+	assert.Nil(t, c.Put("key1", "value1", 10*time.Second))
+	// error is logged, entry is cached
+	assert.Nil(t, c.Put("failure", "value2", 10*time.Second))
+	wg.Wait()
+
+	assert.Equal(t, "value1", store["key1"])
+	assert.True(t, c.Exists("failure"))
+
+	assert.Nil(t, c.Flush())
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// xfetch struct implements probabilistic early expiration (XFetch) of the
+// cache entries loaded by the loader. Entry is refreshed before its expiry
+// with the probability increasing as the expiry approaches, scaled by the
+// cost of computing the value and `beta`. So that many instances don't refresh
+// the same entry at the same instant.
+//
+// It's enabled per cache via config `xfetch.beta = 1.0`, values greater than 1
+// favor earlier refresh. Loader is required for refreshing the entry.
+//
+// Reference: Optimal Probabilistic Cache Stampede Prevention
+// http://www.vldb.org/pvldb/vol8/p886-vattani.pdf
+type xfetch struct {
+	beta  float64
+	group singleflight.Group
+}
+
+// xfetch method reports whether the entry should be refreshed now.
+func (e *entry) xfetch(beta float64) bool {
+	if e.E.IsZero() || e.C <= 0 {
+		return false
+	}
+	gap := time.Duration(float64(e.C) * beta * -math.Log(1-rand.Float64()))
+	return !time.Now().Add(gap).Before(e.E)
+}
+
+// refresh method reloads the entry using the loader and returns the new value.
+// It returns nil if the refresh fails.
+func (r *redisCache) refresh(k string) interface{} {
+	v, err, _ := r.xf.group.Do(k, func() (interface{}, error) {
+		return r.loadAndPut(context.Background(), k, func() (interface{}, time.Duration, error) { return r.loader(k) })
+	})
+	if err != nil {
+		r.logFor(opGet, k).errorf("aah/cache/%s: key(%s) xfetch refresh %v", r.Name(), k, err)
+		return nil
+	}
+	return v
+}
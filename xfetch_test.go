@@ -0,0 +1,54 @@
+// Copyright (c) Jeevanandam M. (https://github.com/jeevatkm)
+// Source code and usage is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package redis
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aahframe.work/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryXFetch(t *testing.T) {
+	e := &entry{D: time.Minute, V: "value"}
+	assert.False(t, e.xfetch(1))
+
+	e.E = time.Now().Add(time.Minute)
+	e.C = time.Millisecond
+	assert.False(t, e.xfetch(1))
+
+	e.E = time.Now().Add(-time.Second)
+	assert.True(t, e.xfetch(1))
+}
+
+func TestRedisXFetch(t *testing.T) {
+	c := createTestCache(t, "redis1", `
+	cache {
+		redis1 {
+			provider = "redis"
+			address = "localhost:6379"
+			xfetch {
+				beta = 1000000000
+			}
+		}
+	}
+`, &cache.Config{Name: "xfetchcache", ProviderName: "redis1"}).(Cache)
+	assert.NotNil(t, c.(*redisCache).xf)
+
+	var calls int32
+	c.SetLoader(func(key string) (interface{}, time.Duration, error) {
+		n := atomic.AddInt32(&calls, 1)
+		time.Sleep(5 * time.Millisecond)
+		return n, 10 * time.Second, nil
+	})
+
+	// large beta with compute cost of 5ms refreshes the entry always
+	assert.Equal(t, int32(1), c.Get("xfetch-key1"))
+	assert.Equal(t, int32(2), c.Get("xfetch-key1"))
+
+	assert.Nil(t, c.Flush())
+}